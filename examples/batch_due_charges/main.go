@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"os"
 	"time"
 
 	"github.com/solviumdream/solviumpayments/pkg/solvium/efi"
@@ -130,4 +132,48 @@ func main() {
 			fmt.Printf("   Contains %d charges\n", len(batch.CobsV))
 		}
 	}
+
+	importCSV(client)
+}
+
+func importCSV(client *efi.Client) {
+	in, err := os.Open("charges.csv")
+	if err != nil {
+		log.Printf("Failed to open charges.csv: %v", err)
+		return
+	}
+	defer in.Close()
+
+	out, err := os.Create("charges-result.csv")
+	if err != nil {
+		log.Printf("Failed to create result file: %v", err)
+		return
+	}
+	defer out.Close()
+
+	importer := efi.NewDueChargeCSVImporter(client.BatchDueCharges())
+	importer.Description = "Monthly tuition"
+	importer.DefaultKey = "your-pix-key"
+	importer.TxIDNamespace = "tuition"
+	importer.Mapping[efi.CSVFieldReference] = "matricula"
+	importer.Mapping[efi.CSVFieldDueDate] = "vencimento"
+	importer.Mapping[efi.CSVFieldDebtorName] = "responsavel"
+	importer.Mapping[efi.CSVFieldDebtorCPF] = "cpf"
+	importer.Mapping[efi.CSVFieldAmount] = "mensalidade"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	rows, err := importer.Import(ctx, in, out)
+	if err != nil {
+		log.Printf("Import finished with error: %v", err)
+	}
+
+	created := 0
+	for _, row := range rows {
+		if row.Status == efi.BatchItemStatusCreated {
+			created++
+		}
+	}
+	fmt.Printf("Created %d of %d charges, see charges-result.csv for details\n", created, len(rows))
 }
@@ -90,4 +90,34 @@ func main() {
 	fmt.Printf("Success: %d\n", summary.Requests.Success)
 	fmt.Printf("Failed: %d\n", summary.Requests.Failed)
 	fmt.Printf("Canceled: %d\n", summary.Requests.Canceled)
+
+	if len(summary.FailedIds) > 0 {
+		fmt.Println("\nExpanding failed payments...")
+		failed, err := client.BillPayment().ExpandFailed(summary)
+		if err != nil {
+			log.Printf("Failed to expand failed payments: %v", err)
+		}
+		for _, f := range failed {
+			fmt.Printf("Payment %s failed: %s\n", f.PaymentID, f.RejectReason)
+		}
+	}
+
+	fmt.Println("\nListing processing payments for the last 7 days...")
+	list, err := client.BillPayment().ListPaymentsByDateRange(7, &efi.ListBillPaymentsOptions{
+		Status: efi.BillPaymentStatusProcessing,
+	})
+	if err != nil {
+		log.Fatalf("Failed to list payments: %v", err)
+	}
+
+	for _, p := range list.Payments {
+		fmt.Printf("Payment %s scheduled for %s\n", p.PaymentID, p.Data.PaymentDate)
+	}
+
+	fmt.Println("\nCanceling scheduled payment...")
+	canceled, err := client.BillPayment().CancelPayment(payment.PaymentID)
+	if err != nil {
+		log.Fatalf("Failed to cancel payment: %v", err)
+	}
+	fmt.Printf("Payment %s is now %s\n", canceled.PaymentID, canceled.Status)
 }
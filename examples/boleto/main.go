@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/solviumdream/solviumpayments/pkg/solvium/boleto"
+	"github.com/solviumdream/solviumpayments/pkg/solvium/efi"
+)
+
+func main() {
+	line := "36490.00001 00001.234566 78901.234563 1 10000000012345"
+
+	b, err := boleto.Parse(line)
+	if err != nil {
+		log.Fatalf("Invalid boleto: %v", err)
+	}
+
+	fmt.Printf("Kind: %s\n", b.Kind)
+	fmt.Printf("Barcode: %s\n", b.Barcode)
+	fmt.Printf("Bank: %s\n", b.BankCode)
+	fmt.Printf("Amount: %s\n", b.Amount())
+	if due, ok := b.DueDate(time.Now()); ok {
+		fmt.Printf("Due date: %s\n", due.Format("2006-01-02"))
+	}
+
+	client, err := efi.NewClientFromP12(
+		"YOUR_CLIENT_ID",
+		"YOUR_CLIENT_SECRET",
+		"path/to/certificate.p12",
+		"",
+		efi.Sandbox,
+	)
+	if err != nil {
+		log.Fatalf("Failed to create Efi client: %v", err)
+	}
+
+	payment, err := client.BillPayment().RequestPayment(b.Barcode, &efi.BillPaymentRequest{
+		Value:       b.AmountFloat(),
+		PaymentDate: time.Now().Format("2006-01-02"),
+		Description: "Validated offline before payment",
+	})
+	if err != nil {
+		log.Fatalf("Failed to request payment: %v", err)
+	}
+
+	fmt.Printf("Payment %s requested with status %s\n", payment.PaymentID, payment.Status)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/solviumdream/solviumpayments/pkg/solvium/efi"
+	"github.com/solviumdream/solviumpayments/pkg/solvium/efi/charges"
+)
+
+func main() {
+	client, err := efi.NewClientFromP12(
+		"YOUR_CLIENT_ID",
+		"YOUR_CLIENT_SECRET",
+		"path/to/certificate.p12",
+		"",
+		efi.Sandbox,
+	)
+	if err != nil {
+		log.Fatalf("Failed to create Efi client: %v", err)
+	}
+
+	billing := charges.NewClient(client)
+
+	customer := charges.Customer{
+		Name:        "Gorbadoc Oldbuck",
+		CPF:         "94271564656",
+		Email:       "oldbuck@example.com",
+		PhoneNumber: "5144916523",
+	}
+
+	items := []charges.Item{
+		{Name: "Product 1", Value: 5990, Amount: 2},
+	}
+
+	billet, err := billing.Charges().CreateBillet(
+		charges.CreateChargeRequest{
+			Items:    items,
+			Metadata: &charges.Metadata{CustomID: "order-1001", NotificationURL: "https://your-server.com/efi/notifications"},
+		},
+		charges.BankingBillet{
+			Customer:       customer,
+			ExpireAt:       time.Now().AddDate(0, 0, 5).Format("2006-01-02"),
+			Configurations: &charges.Configurations{Fine: 200, Interest: 33},
+		},
+	)
+	if err != nil {
+		log.Fatalf("Failed to create billet: %v", err)
+	}
+
+	fmt.Printf("Billet created - Charge ID: %d, Barcode: %s\n", billet.ChargeID, billet.Barcode)
+	if billet.IsBolix() {
+		fmt.Printf("Pix QR code: %s\n", billet.Pix.QRCode)
+	}
+
+	charge, err := billing.Charges().Create(charges.CreateChargeRequest{Items: items})
+	if err != nil {
+		log.Fatalf("Failed to create charge: %v", err)
+	}
+
+	link, err := billing.PaymentLinks().Define(charge.ChargeID, charges.PaymentLinkSettings{
+		PaymentMethod: charges.PaymentMethodAll,
+		ExpireAt:      time.Now().AddDate(0, 0, 10).Format("2006-01-02"),
+		Message:       "Thanks for your purchase",
+	})
+	if err != nil {
+		log.Printf("Failed to define payment link: %v", err)
+	} else {
+		fmt.Printf("Payment link: %s\n", link.PaymentURL)
+	}
+
+	carnet, err := billing.Carnets().Create(charges.CreateCarnetRequest{
+		Items:    items,
+		Customer: customer,
+		ExpireAt: time.Now().AddDate(0, 1, 0).Format("2006-01-02"),
+		Repeats:  6,
+	})
+	if err != nil {
+		log.Printf("Failed to create carnet: %v", err)
+	} else {
+		fmt.Printf("Carnet %d created with %d parcels\n", carnet.CarnetID, len(carnet.Charges))
+	}
+
+	if err := billing.Charges().UpdateDueDate(billet.ChargeID, time.Now().AddDate(0, 0, 15).Format("2006-01-02")); err != nil {
+		log.Printf("Failed to update due date: %v", err)
+	}
+
+	if err := billing.Charges().Cancel(charge.ChargeID); err != nil {
+		log.Printf("Failed to cancel charge: %v", err)
+	}
+
+	notification, err := billing.Notifications().Get("YOUR_NOTIFICATION_TOKEN")
+	if err != nil {
+		log.Printf("Failed to get notification: %v", err)
+	} else {
+		for subject, event := range notification.Latest() {
+			fmt.Printf("%s %d is now %s (paid: %t)\n", subject.Type, subject.ID, event.Status.Current, event.IsPaid())
+		}
+	}
+}
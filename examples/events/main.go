@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/solviumdream/solviumpayments/pkg/solvium/efi"
+	"github.com/solviumdream/solviumpayments/pkg/solvium/events"
+	"github.com/solviumdream/solviumpayments/pkg/solvium/mercadopago"
+)
+
+func main() {
+	client, err := efi.NewClientFromP12(
+		"YOUR_CLIENT_ID",
+		"YOUR_CLIENT_SECRET",
+		"path/to/certificate.p12",
+		"",
+		efi.Sandbox,
+	)
+	if err != nil {
+		log.Fatalf("Failed to create Efi client: %v", err)
+	}
+
+	bus := events.NewBus(4)
+	defer bus.Close()
+
+	bus.OnError = func(event events.Event, err error) {
+		log.Printf("Handler failed for %s on %s: %v", event.Type(), event.ResourceID(), err)
+	}
+
+	events.Subscribe(bus, func(ctx context.Context, event events.ChargePaid) error {
+		fmt.Printf("Charge %s paid: %s (%s)\n", event.ResourceID(), efi.FormatAmount(event.Amount), event.Source)
+		return nil
+	})
+	events.Subscribe(bus, func(ctx context.Context, event events.PixSendFailed) error {
+		fmt.Printf("Pix send %s failed: %s\n", event.IDEnvio, event.Reason)
+		return nil
+	})
+	events.Subscribe(bus, func(ctx context.Context, event events.BillPaid) error {
+		fmt.Printf("Bill %s paid\n", event.Identifier)
+		return nil
+	})
+
+	openFinance := client.OpenFinance().WebhookHandler(&efi.OpenFinanceConfig{})
+	events.AttachOpenFinance(bus, openFinance)
+
+	mp := mercadopago.NewClient("YOUR_MERCADOPAGO_ACCESS_TOKEN", mercadopago.Sandbox)
+
+	go func() {
+		changes := client.StatusWatcher().Watch(context.Background(),
+			efi.TransactionRef{ID: "YOUR_TXID", Type: efi.TransactionTypeCharge},
+			efi.TransactionRef{ID: "YOUR_ID_ENVIO", Type: efi.TransactionTypePixSend},
+		)
+		if err := events.ForwardStatusChanges(context.Background(), bus, changes); err != nil {
+			log.Printf("Status polling stopped: %v", err)
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.Handle("/webhooks/pix", events.PixWebhookHandler(bus))
+	mux.Handle("/webhooks/bill-payment", events.BillPaymentWebhookHandler(bus))
+	mux.Handle("/webhooks/open-finance", openFinance)
+	mux.Handle("/webhooks/mercadopago", events.MercadoPagoNotificationHandler(bus, mp))
+
+	log.Fatal(http.ListenAndServe(":8080", mux))
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/solviumdream/solviumpayments/pkg/solvium/efi"
+)
+
+func main() {
+	client, err := efi.NewClientFromP12(
+		"YOUR_CLIENT_ID",
+		"YOUR_CLIENT_SECRET",
+		"path/to/certificate.p12",
+		"",
+		efi.Sandbox,
+	)
+	if err != nil {
+		log.Fatalf("Failed to create Efi client: %v", err)
+	}
+
+	charges := efi.NewIdempotentCharges(client, efi.NewMemoryChargeIntentStore(), "my-store")
+
+	request := efi.CreateImmediateChargeRequest{
+		Calendario: efi.Calendario{Expiracao: 3600},
+		Valor:      efi.Valor{Original: "199.90"},
+		Chave:      "your-pix-key",
+	}
+
+	ctx := context.Background()
+	for attempt := 1; attempt <= 2; attempt++ {
+		result, err := charges.CreateImmediate(ctx, "order-2024-0001", request)
+		if err != nil {
+			log.Fatalf("Failed to create charge: %v", err)
+		}
+
+		if result.Created {
+			fmt.Printf("Attempt %d created charge %s\n", attempt, result.Charge.TxID)
+		} else {
+			fmt.Printf("Attempt %d found existing charge %s (%s)\n", attempt, result.Charge.TxID, result.Charge.Status)
+		}
+	}
+
+	fmt.Printf("Order order-2024-0001 always maps to txid %s\n", efi.TxIDForOrder("my-store", "order-2024-0001"))
+}
@@ -87,4 +87,30 @@ func main() {
 	}
 
 	fmt.Printf("Updated charge value: %s\n", updatedCharge.Valor.Original)
+
+	trocoCharge, err := client.ImmediateCharge().CreateWithoutTxid(efi.CreateImmediateChargeRequest{
+		Calendario: efi.Calendario{Expiracao: 600},
+		Valor: efi.Valor{
+			Original: "30.00",
+			Retirada: &efi.Retirada{
+				Troco: &efi.RetiradaValor{
+					Valor:                     "20.00",
+					ModalidadeAgente:          efi.ModalidadeAgenteEstabelecimento,
+					PrestadorDoServicoDeSaque: efi.ISPBEfi,
+				},
+			},
+		},
+		Chave: "your-pix-key",
+	})
+	if err != nil {
+		log.Printf("Failed to create Pix Troco charge: %v", err)
+		return
+	}
+
+	for _, pix := range trocoCharge.Pix {
+		if pix.ComponentesValor.IsWithdrawal() {
+			troco, _ := pix.ComponentesValor.TrocoAmount()
+			fmt.Printf("Pix %s included %s of change\n", pix.EndToEndID, efi.FormatAmount(troco))
+		}
+	}
 }
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/solviumdream/solviumpayments/pkg/solvium/efi"
+	"github.com/solviumdream/solviumpayments/pkg/solvium/events"
+	"github.com/solviumdream/solviumpayments/pkg/solvium/inbox"
+	"github.com/solviumdream/solviumpayments/pkg/solvium/mercadopago"
+)
+
+func main() {
+	store, err := inbox.OpenFileStore("webhooks-inbox.json")
+	if err != nil {
+		log.Fatalf("Failed to open inbox store: %v", err)
+	}
+	defer store.Close()
+
+	bus := events.NewBus(4)
+	defer bus.Close()
+
+	events.Subscribe(bus, func(ctx context.Context, event events.ChargePaid) error {
+		fmt.Printf("Charge %s paid: %s\n", event.ResourceID(), efi.FormatAmount(event.Amount))
+		return nil
+	})
+	events.Subscribe(bus, func(ctx context.Context, event events.BillPaid) error {
+		fmt.Printf("Bill %s paid\n", event.Identifier)
+		return nil
+	})
+
+	box := inbox.New(store)
+	box.MaxAttempts = 10
+	box.OnDeadLetter = func(entry inbox.Entry) {
+		log.Printf("Webhook %s moved to dead letters: %s", entry.Key, entry.LastError)
+	}
+	box.DispatchTo(bus, mercadopago.NewClient("YOUR_MERCADOPAGO_ACCESS_TOKEN", mercadopago.Sandbox))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		if err := box.Run(ctx, 5*time.Second); err != nil && ctx.Err() == nil {
+			log.Printf("Inbox processing stopped: %v", err)
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.Handle("/webhooks/pix", box.PixWebhookHandler())
+	mux.Handle("/webhooks/bill-payment", box.BillPaymentWebhookHandler())
+	mux.Handle("/webhooks/open-finance", box.OpenFinanceWebhookHandler(nil))
+	mux.Handle("/webhooks/mercadopago", box.MercadoPagoHandler())
+	mux.HandleFunc("/webhooks/dead-letters", func(w http.ResponseWriter, r *http.Request) {
+		if key := r.URL.Query().Get("requeue"); key != "" {
+			if err := box.Requeue(key); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		dead, err := box.DeadLetters()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, entry := range dead {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", entry.Key, entry.Source, entry.Attempts, entry.LastError)
+		}
+	})
+
+	log.Fatal(http.ListenAndServe(":8080", mux))
+}
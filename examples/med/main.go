@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/solviumdream/solviumpayments/pkg/solvium/efi"
+)
+
+func main() {
+	client, err := efi.NewClientFromP12(
+		"YOUR_CLIENT_ID",
+		"YOUR_CLIENT_SECRET",
+		"path/to/certificate.p12",
+		"",
+		efi.Sandbox,
+	)
+	if err != nil {
+		log.Fatalf("Failed to create Efi client: %v", err)
+	}
+
+	endDate := time.Now()
+	startDate := endDate.AddDate(0, 0, -30)
+
+	infractions, err := client.MED().ListInfractions(startDate, endDate, &efi.ListMEDOptions{
+		Status: efi.MEDInfractionStatusOpen,
+	})
+	if err != nil {
+		log.Fatalf("Failed to list infractions: %v", err)
+	}
+
+	for _, infraction := range infractions.Infracoes {
+		detail, err := client.MED().GetInfractionDetail(infraction.ID, startDate, endDate)
+		if err != nil {
+			log.Printf("Failed to get infraction %s: %v", infraction.ID, err)
+			continue
+		}
+
+		fmt.Printf("Infraction %s on Pix %s (value %s, txid %s)\n",
+			infraction.ID, infraction.EndToEndID, detail.Pix.Valor, detail.Pix.TxID)
+
+		for _, refund := range detail.SpecialRefunds {
+			fmt.Printf("  Special refund %s: %s %s\n", refund.ID, refund.Valor, refund.Status)
+		}
+	}
+
+	_, err = client.MED().SubmitDefense("YOUR_INFRACTION_ID", efi.MEDDefenseRequest{
+		Analise:       efi.MEDDefenseRejected,
+		Justificativa: "The product was delivered as agreed",
+		Anexos: []efi.MEDAttachment{
+			{Nome: "delivery-receipt.pdf", Tipo: "application/pdf", Tamanho: 48213, Hash: "YOUR_SHA256_HASH"},
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to submit defense: %v", err)
+	}
+}
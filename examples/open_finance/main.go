@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"net/url"
 	"os"
 	"time"
@@ -113,6 +115,51 @@ func demonstratePayments(client *efi.Client) {
 	}
 }
 
+func demonstratePaymentLookup(client *efi.Client) {
+	payment, err := client.OpenFinance().GetPaymentByOwnID("order-1001")
+	if err != nil {
+		log.Printf("Failed to get payment by own ID: %v", err)
+	} else {
+		fmt.Printf("Payment %s is %s\n", payment.PaymentID, payment.Status)
+	}
+
+	fmt.Println("\nIterating over all completed payments in the last 90 days...")
+	payments := client.OpenFinance().IteratePayments(time.Now().AddDate(0, 0, -90), time.Now(), &efi.ListOpenFinancePaymentsOptions{
+		Status: efi.PaymentStatusCompleted,
+		Limit:  50,
+	})
+
+	ctx := context.Background()
+	for payments.Next(ctx) {
+		payment := payments.Item()
+		fmt.Printf("%s %s %s\n", payment.PaymentID, payment.Value, payment.CreatedAt)
+	}
+	if err := payments.Err(); err != nil {
+		log.Printf("Failed to iterate payments: %v", err)
+	}
+}
+
+func newWebhookHandler(client *efi.Client) http.Handler {
+	config, err := client.OpenFinance().GetApplicationSettings()
+	if err != nil {
+		log.Fatalf("Failed to get Open Finance config: %v", err)
+	}
+
+	handler := client.OpenFinance().WebhookHandler(config)
+
+	handler.OnPayment(func(event efi.OpenFinancePaymentEvent) error {
+		fmt.Printf("Payment %s (idProprio %s) is now %s\n", event.PaymentID, event.OwnID, event.Status)
+		return nil
+	})
+
+	handler.OnRefund(func(event efi.OpenFinanceRefundEvent) error {
+		fmt.Printf("Refund %s for payment %s is now %s\n", event.RefundID, event.PaymentID, event.Status)
+		return nil
+	})
+
+	return handler
+}
+
 func createSampleBankAccountPayment() *efi.OpenFinancePaymentRequest {
 	return &efi.OpenFinancePaymentRequest{
 		Payer: efi.OpenFinancePaymentPayer{
@@ -247,6 +294,24 @@ func listParticipants(client *efi.Client) {
 	}
 }
 
+func searchParticipantDirectory(client *efi.Client) {
+	directory := client.OpenFinance().ParticipantDirectory()
+	directory.TTL = time.Hour
+	directory.Start(context.Background())
+
+	results, err := directory.Search("itau unibanco", 5)
+	if err != nil {
+		log.Printf("Failed to search participants: %v", err)
+		return
+	}
+
+	for _, participant := range results {
+		fmt.Printf("- %s %s %s\n", participant.Name, participant.Logo, participant.Portal)
+	}
+
+	http.Handle("/banks", directory)
+}
+
 func configureApplication(client *efi.Client) {
 	
 	fmt.Println("Enabling receive without key for Open Finance...")
@@ -338,6 +403,40 @@ func parseRedirectParams() {
 	}
 }
 
+func newRedirectFlow(client *efi.Client) *http.ServeMux {
+	flow := client.OpenFinance().Flow(efi.NewMemoryOpenFinanceFlowStore())
+	flow.SessionID = func(r *http.Request) (string, error) {
+		cookie, err := r.Cookie("session")
+		if err != nil {
+			return "", err
+		}
+		return cookie.Value, nil
+	}
+	flow.OnComplete = func(w http.ResponseWriter, r *http.Request, result *efi.OpenFinanceFlowResult, err error) {
+		switch {
+		case err != nil:
+			http.Error(w, "Could not confirm your payment", http.StatusBadRequest)
+		case result.Error != nil:
+			fmt.Fprintf(w, "Payment not authorized: %s", result.Error.Message)
+		default:
+			fmt.Fprintf(w, "Payment %s is %s", result.State.PaymentID, result.State.PaymentStatus)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/checkout", func(w http.ResponseWriter, r *http.Request) {
+		state, err := flow.Begin(w, r, createSamplePixKeyPayment())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		http.Redirect(w, r, state.RedirectURI, http.StatusFound)
+	})
+	mux.Handle("/efi/redirect", flow)
+
+	return mux
+}
+
 func demonstrateScheduledPayments(client *efi.Client) {
 	
 	fmt.Println("Initiating a scheduled payment with bank account...")
@@ -427,6 +526,79 @@ func demonstrateScheduledPayments(client *efi.Client) {
 	}
 }
 
+func demonstrateRecurringPayments(client *efi.Client) {
+	request := &efi.OpenFinanceRecurringPaymentRequest{
+		Payer: efi.OpenFinancePaymentPayer{
+			ParticipantID: "9f4cd202-8f2b-11ec-b909-0242ac120002",
+			CPF:           "45204392050",
+		},
+		Recipient: efi.OpenFinanceRecipient{
+			PixKey: &efi.OpenFinancePixKey{
+				KeyType: "email",
+				Key:     "merchant@example.com",
+			},
+		},
+		Payment: efi.OpenFinanceRecurringPaymentInfo{
+			Value:     "49.90",
+			PayerInfo: "Monthly subscription",
+			OwnID:     "subscription-42",
+			Recurrence: efi.OpenFinanceRecurrence{
+				Type:       efi.RecurrenceMonthly,
+				StartDate:  time.Now().AddDate(0, 1, 0).Format("2006-01-02"),
+				Quantity:   12,
+				DayOfMonth: 10,
+			},
+		},
+	}
+
+	payment, err := client.OpenFinance().InitiateRecurringPayment(request)
+	if err != nil {
+		log.Printf("Failed to initiate recurring payment: %v", err)
+		return
+	}
+	fmt.Printf("Recurring payment %s created, redirect the payer to %s\n", payment.PaymentID, payment.RedirectURI)
+
+	series, err := client.OpenFinance().GetRecurringPayment(payment.PaymentID)
+	if err != nil {
+		log.Printf("Failed to get recurring payment: %v", err)
+		return
+	}
+
+	for _, occurrence := range series.Occurrences {
+		fmt.Printf("  %s %s %s\n", occurrence.ScheduledDate, occurrence.Value, occurrence.Status)
+	}
+
+	if len(series.Occurrences) > 1 {
+		skipped := series.Occurrences[1]
+		if _, err := client.OpenFinance().CancelRecurringOccurrence(series.PaymentID, skipped.EndToEndID); err != nil {
+			log.Printf("Failed to cancel occurrence: %v", err)
+		}
+	}
+
+	consent, err := client.OpenFinance().CreateSweepingConsent(&efi.OpenFinanceSweepingConsentRequest{
+		Payer: request.Payer,
+		Recipient: efi.OpenFinanceRecipient{
+			BankAccount: &efi.OpenFinanceBankAccount{
+				Name:        "John Doe",
+				Document:    "45204392050",
+				BankCode:    "09089356",
+				Branch:      "0001",
+				Account:     "123456",
+				AccountType: efi.AccountTypeChecking,
+			},
+		},
+		Limits: efi.OpenFinanceSweepingLimits{
+			TransactionValue: "1000.00",
+			Month:            &efi.OpenFinancePeriodicLimit{Value: "5000.00", Quantity: 10},
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to create sweeping consent: %v", err)
+		return
+	}
+	fmt.Printf("Sweeping consent %s created, redirect the payer to %s\n", consent.ConsentID, consent.RedirectURI)
+}
+
 func createSampleScheduledBankAccountPayment(scheduledDate string) *efi.OpenFinanceScheduledPaymentRequest {
 	return &efi.OpenFinanceScheduledPaymentRequest{
 		Payer: efi.OpenFinancePaymentPayer{
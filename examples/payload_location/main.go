@@ -94,4 +94,32 @@ func main() {
 			}
 		}
 	}
+
+	pool := client.PayloadLocationPool(5)
+	if loaded, err := pool.LoadUnlinked(startDate, endDate); err != nil {
+		log.Printf("Failed to load unlinked locations: %v", err)
+	} else {
+		fmt.Printf("Reused %d unlinked locations\n", loaded)
+	}
+
+	if err := pool.Fill(efi.PayloadLocationTypeCOB); err != nil {
+		log.Printf("Failed to fill location pool: %v", err)
+		return
+	}
+	fmt.Printf("%d cob locations ready to print\n", pool.Available(efi.PayloadLocationTypeCOB))
+
+	charge, err := pool.CreateImmediateCharge("", efi.CreateImmediateChargeRequest{
+		Calendario: efi.Calendario{Expiracao: 3600},
+		Valor:      efi.Valor{Original: "25.00"},
+		Chave:      "your-pix-key",
+	})
+	if err != nil {
+		log.Printf("Failed to create charge with pooled location: %v", err)
+		return
+	}
+	fmt.Printf("Charge %s uses location %d\n", charge.TxID, charge.Loc.ID)
+
+	if err := pool.Recycle(int64(charge.Loc.ID)); err != nil {
+		log.Printf("Failed to recycle location: %v", err)
+	}
 }
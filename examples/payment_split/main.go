@@ -179,4 +179,27 @@ func main() {
 			}
 		}
 	}
+
+	configs, err := client.PaymentSplit().ListConfigs(time.Now().AddDate(0, -1, 0), time.Now(), &efi.ListPaymentSplitConfigsOptions{
+		ItensPorPagina: 50,
+	})
+	if err != nil {
+		log.Printf("Failed to list split configs: %v", err)
+	} else {
+		fmt.Printf("Found %d split configs\n", len(configs.Configs))
+	}
+
+	if splitConfigResp != nil && splitConfigResp.ID != "" {
+		history, err := client.PaymentSplit().ConfigHistory(splitConfigResp.ID)
+		if err != nil {
+			log.Printf("Failed to get split config history: %v", err)
+		} else {
+			for _, diff := range history.Diffs {
+				fmt.Printf("Revision %d -> %d\n", diff.FromRevisao, diff.ToRevisao)
+				for _, change := range diff.Changes {
+					fmt.Printf("  %s %s: %q -> %q\n", change.Kind, change.Field, change.From, change.To)
+				}
+			}
+		}
+	}
 }
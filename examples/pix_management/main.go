@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -102,4 +104,57 @@ func main() {
 			testRefundResp.ID, testRefundResp.Status)
 		fmt.Println("This refund should be rejected in the sandbox environment")
 	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	partial, err := client.Refunds().Refund(ctx, e2eIDForRefund, "order-123-partial", "5.00")
+	if errors.Is(err, efi.ErrRefundExceedsBalance) {
+		fmt.Println("Refund rejected locally: not enough refundable balance")
+	} else if err != nil {
+		log.Printf("Failed to refund: %v", err)
+	} else {
+		fmt.Printf("Partial refund %s requested for %s\n", partial.RefundID, partial.Valor)
+	}
+
+	outcomes, err := client.Refunds().RefundTxID(ctx, "your-charge-txid", "order-123-cancel", time.Now().AddDate(0, 0, -30), time.Now())
+	if err != nil {
+		log.Printf("Failed to refund txid: %v", err)
+	}
+
+	for _, outcome := range client.Refunds().FollowAll(ctx, outcomes) {
+		if outcome.Err != nil {
+			fmt.Printf("Refund of %s failed: %v\n", outcome.EndToEndID, outcome.Err)
+			continue
+		}
+		fmt.Printf("Refund %s of %s on %s finished as %s\n", outcome.RefundID, outcome.Valor, outcome.EndToEndID, outcome.Refund.Status)
+	}
+
+	received := client.PixManagement().IterateReceived(time.Now().AddDate(0, 0, -30), time.Now(), nil, &efi.PageOptions{
+		PageSize: 100,
+		Prefetch: 2,
+	})
+	var total int64
+	err = received.ForEach(context.Background(), func(pix efi.PixDetail) error {
+		cents, err := efi.ParseAmount(pix.Valor)
+		if err != nil {
+			return err
+		}
+		total += cents
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to iterate received Pix: %v", err)
+	} else {
+		fmt.Printf("Received %s over the last 30 days\n", efi.FormatAmount(total))
+	}
+
+	sent := client.PixSend().Iterate(time.Now().AddDate(0, 0, -7), time.Now(), &efi.ListSentOptions{Status: efi.StatusPixSendFailed}, nil)
+	for sent.Next(context.Background()) {
+		detail := sent.Item()
+		fmt.Printf("Failed send %s: %s\n", detail.EndToEndID, detail.Valor)
+	}
+	if err := sent.Err(); err != nil {
+		log.Printf("Failed to iterate sent Pix: %v", err)
+	}
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -106,4 +107,62 @@ func main() {
 		fmt.Printf("QR code payment - ID: %s, Status: %s, Value: %s\n",
 			qrPayResp.IDEnvio, qrPayResp.Status, qrPayResp.Valor)
 	}
+
+	ispb, ok := efi.LookupISPBByCompe("341")
+	if !ok {
+		log.Fatalf("Unknown bank code 341")
+	}
+
+	bankResp, err := client.PixSend().SendToBankAccount("SUPPLIER0001PAYMENT", "150.00", efi.PagadorSend{
+		Chave:       "YOUR_PIX_KEY",
+		InfoPagador: "Supplier payment",
+	}, efi.ContaBanco{
+		Nome:      "Supplier Ltda",
+		CNPJ:      "12345678000199",
+		Codigo:    ispb,
+		Agencia:   "1234",
+		Conta:     "123456",
+		TipoConta: efi.ContaBancoCorrente,
+	})
+	if err != nil {
+		log.Printf("Failed to send Pix to bank account: %v", err)
+	} else {
+		fmt.Printf("Bank account Pix - ID: %s, Status: %s\n", bankResp.IDEnvio, bankResp.Status)
+	}
+
+	demonstratePayouts(client)
+}
+
+func demonstratePayouts(client *efi.Client) {
+	journal, err := efi.OpenFilePayoutJournal("payouts.journal")
+	if err != nil {
+		log.Printf("Failed to open payout journal: %v", err)
+		return
+	}
+	defer journal.Close()
+
+	engine := efi.NewPayoutEngine(client, journal, "commissions-2024-12", "YOUR_PIX_KEY")
+	engine.Concurrency = 2
+
+	payouts := []efi.Payout{
+		{Reference: "seller-001", Valor: "120.50", Favorecido: efi.Favorecido{Chave: "seller1@example.com"}, InfoPagador: "December commission"},
+		{Reference: "seller-002", Valor: "87.00", Favorecido: efi.Favorecido{Chave: "+5511999999999"}, InfoPagador: "December commission"},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	summary, err := engine.Run(ctx, payouts)
+	if err != nil {
+		log.Printf("Payout run interrupted: %v", err)
+	}
+	if summary == nil {
+		return
+	}
+
+	for _, result := range summary.Results {
+		fmt.Printf("Payout %s (%s): %s %s %s\n", result.Reference, result.IDEnvio, result.State, result.Status, result.Error)
+	}
+	fmt.Printf("Completed %d, failed %d, pending %d, unknown %d, total paid %s\n",
+		summary.Completed, summary.Failed, summary.Pending, summary.Unknown, efi.FormatAmount(summary.CompletedCents))
 }
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/solviumdream/solviumpayments/pkg/solvium/efi"
+)
+
+func main() {
+	client, err := efi.NewClientFromP12(
+		"YOUR_CLIENT_ID",
+		"YOUR_CLIENT_SECRET",
+		"path/to/certificate.p12",
+		"",
+		efi.Sandbox,
+	)
+	if err != nil {
+		log.Fatalf("Failed to create Efi client: %v", err)
+	}
+
+	now := time.Now()
+	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -1, 0)
+	endDate := startDate.AddDate(0, 1, 0).Add(-time.Second)
+
+	expected := []efi.ExpectedReceivable{
+		{Reference: "invoice-1001", TxID: "invoice1001txid00000000000000", Amount: "150.00"},
+		{Reference: "invoice-1002", TxID: "invoice1002txid00000000000000", Amount: "89.90"},
+		{Reference: "invoice-1003", Amount: "42.00", Document: "12345678909"},
+	}
+
+	report, err := efi.NewReconciler(client).Reconcile(context.Background(), startDate, endDate, expected)
+	if err != nil {
+		log.Fatalf("Failed to reconcile: %v", err)
+	}
+
+	fmt.Printf("Reconciliation from %s to %s\n", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	for category, count := range report.Counts {
+		fmt.Printf("  %-15s %d\n", category, count)
+	}
+
+	for _, entry := range report.Entries {
+		reference := entry.TxID
+		if entry.Expected != nil {
+			reference = entry.Expected.Reference
+		}
+
+		fmt.Printf("%-15s %-30s expected %s received %s refunded %s",
+			entry.Category, reference,
+			efi.FormatAmount(entry.ExpectedCents),
+			efi.FormatAmount(entry.ReceivedCents),
+			efi.FormatAmount(entry.RefundedCents))
+		if entry.DocumentMismatch {
+			fmt.Print(" (payer document mismatch)")
+		}
+		if entry.Note != "" {
+			fmt.Printf(" - %s", entry.Note)
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/solviumdream/solviumpayments/pkg/solvium/efi"
+)
+
+func main() {
+	client, err := efi.NewClientFromP12(
+		"YOUR_CLIENT_ID",
+		"YOUR_CLIENT_SECRET",
+		"path/to/certificate.p12",
+		"",
+		efi.Sandbox,
+	)
+	if err != nil {
+		log.Fatalf("Failed to create Efi client: %v", err)
+	}
+
+	endDate := time.Now()
+	startDate := endDate.AddDate(0, 0, -7)
+
+	request, err := client.Statements().Request(efi.StatementRequest{
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+		Format:    efi.StatementFormatCNAB240,
+	})
+	if err != nil {
+		log.Fatalf("Failed to request statement: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	request, err = client.Statements().WaitForRequest(ctx, request.ID, 10*time.Second)
+	if err != nil {
+		log.Fatalf("Statement was not generated: %v", err)
+	}
+
+	statement, err := client.Statements().DownloadAndParse(request.FileName)
+	if err != nil {
+		log.Fatalf("Failed to download statement: %v", err)
+	}
+
+	fmt.Printf("Statement for account %s has %d entries\n", statement.Account.Account, len(statement.Entries))
+
+	received, err := client.PixManagement().ListReceived(startDate, endDate, nil)
+	if err != nil {
+		log.Fatalf("Failed to list received Pix: %v", err)
+	}
+
+	for _, match := range efi.JoinReceived(statement.Entries, received.Pix) {
+		if match.Pix == nil {
+			fmt.Printf("%s %s %s - no matching Pix\n", match.Entry.Date.Format("2006-01-02"), match.Entry.Type, match.Entry.Valor)
+			continue
+		}
+		fmt.Printf("%s %s %s - Pix %s (txid %s)\n", match.Entry.Date.Format("2006-01-02"), match.Entry.Type, match.Entry.Valor, match.Pix.EndToEndID, match.Pix.TxID)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/solviumdream/solviumpayments/pkg/solvium/efi"
+)
+
+func main() {
+	client, err := efi.NewClientFromP12(
+		"YOUR_CLIENT_ID",
+		"YOUR_CLIENT_SECRET",
+		"path/to/certificate.p12",
+		"",
+		efi.Sandbox,
+	)
+	if err != nil {
+		log.Fatalf("Failed to create Efi client: %v", err)
+	}
+
+	store := efi.NewMemorySubscriptionStore()
+	store.SavePlan(efi.SubscriptionPlan{
+		ID:                     "basic",
+		Name:                   "Basic",
+		Amount:                 "49.90",
+		Chave:                  "YOUR_PIX_KEY",
+		SolicitacaoPagador:     "Plano Basic",
+		ValidadeAposVencimento: 15,
+		Multa:                  efi.Multa{Modalidade: 2, ValorPerc: "2.00"},
+		Juros:                  efi.Juros{Modalidade: 2, ValorPerc: "1.00"},
+		Desconto:               efi.Desconto{Modalidade: 2, ValorPerc: "5.00"},
+		DiscountDaysBeforeDue:  5,
+	})
+	store.SavePlan(efi.SubscriptionPlan{
+		ID:                     "pro",
+		Name:                   "Pro",
+		Amount:                 "99.90",
+		Chave:                  "YOUR_PIX_KEY",
+		SolicitacaoPagador:     "Plano Pro",
+		ValidadeAposVencimento: 15,
+		Multa:                  efi.Multa{Modalidade: 2, ValorPerc: "2.00"},
+		Juros:                  efi.Juros{Modalidade: 2, ValorPerc: "1.00"},
+	})
+	store.SaveCustomer(efi.SubscriptionCustomer{
+		ID: "customer-1",
+		Devedor: efi.DevedorDueCharge{
+			CPF:   "12345678909",
+			Nome:  "Francisco da Silva",
+			Email: "francisco@example.com",
+		},
+	})
+	store.SaveSubscription(efi.Subscription{
+		ID:         "subscription-1",
+		CustomerID: "customer-1",
+		PlanID:     "basic",
+		Status:     efi.SubscriptionActive,
+		StartDate:  time.Now().AddDate(0, -2, 0),
+		PlanChanges: []efi.SubscriptionPlanChange{
+			{EffectiveAt: time.Now().AddDate(0, 0, -10), PlanID: "pro"},
+		},
+	})
+
+	billing := efi.NewSubscriptionBilling(client, store, efi.NewMemoryChargeIntentStore(), "subscriptions")
+
+	preview, err := billing.Preview("subscription-1", 2)
+	if err != nil {
+		log.Printf("Failed to preview invoice: %v", err)
+	} else {
+		fmt.Printf("Next invoice due %s: %s (adjustment %s)\n",
+			preview.DueDate, efi.FormatAmount(preview.Charged), efi.FormatAmount(preview.Adjustment))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	report, err := billing.Run(ctx, time.Now())
+	if err != nil {
+		log.Fatalf("Subscription run failed: %v", err)
+	}
+
+	for _, invoice := range report.Issued {
+		fmt.Printf("Issued %s cycle %d: txid %s, %s due %s\n",
+			invoice.SubscriptionID, invoice.Cycle, invoice.TxID, efi.FormatAmount(invoice.Charged), invoice.DueDate)
+	}
+	for _, failure := range report.Errors {
+		log.Printf("Failed to bill %s cycle %d: %v", failure.SubscriptionID, failure.Cycle, failure.Err)
+	}
+	fmt.Printf("Issued %d, waived %d, already issued %d\n", len(report.Issued), len(report.Waived), report.Skipped)
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net/http"
+	"time"
 
 	"github.com/solviumdream/solviumpayments/pkg/solvium/efi"
 )
@@ -105,4 +108,34 @@ func main() {
 			
 		}
 	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	watcher := client.StatusWatcher()
+
+	paid, err := watcher.WaitFor(ctx, chargeTxID, efi.TransactionTypeCharge, efi.StatusChargeCompleted)
+	if err != nil {
+		log.Printf("Charge was not paid: %v", err)
+	} else {
+		fmt.Printf("Charge %s paid: %s\n", paid.ID, paid.Message)
+	}
+
+	changes := watcher.Watch(ctx,
+		efi.TransactionRef{ID: pixSendID, Type: efi.TransactionTypePixSend},
+		efi.TransactionRef{ID: combinedID, Type: efi.TransactionTypeRefund},
+	)
+
+	http.HandleFunc("/webhook/pix-send", func(w http.ResponseWriter, r *http.Request) {
+		watcher.Feed(r.URL.Query().Get("idEnvio"), efi.TransactionTypePixSend, r.URL.Query().Get("status"))
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for change := range changes {
+		if change.Err != nil {
+			log.Printf("Error watching %s: %v", change.Ref.ID, change.Err)
+			continue
+		}
+		fmt.Printf("%s %s -> %s (%s)\n", change.Ref.ID, change.Previous, change.Status.Status, change.Source)
+	}
 }
@@ -14,6 +14,7 @@ func main() {
 	fmt.Println("A modular payment solution for Go projects")
 	fmt.Println("Currently supported payment providers:")
 	fmt.Println("- Efi Payments Pix API")
+	fmt.Println("- Efi Payments Cobranças API")
 	fmt.Println("\nFor usage examples, see the examples directory")
 }
 
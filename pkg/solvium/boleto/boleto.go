@@ -0,0 +1,296 @@
+package boleto
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidLength     = errors.New("invalid barcode or digitable line length")
+	ErrInvalidCharacters = errors.New("barcode and digitable line must contain only digits")
+	ErrInvalidCheckDigit = errors.New("invalid check digit")
+)
+
+type Kind int
+
+const (
+	KindBank Kind = iota + 1
+	KindCollection
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindBank:
+		return "bank"
+	case KindCollection:
+		return "collection"
+	}
+	return "unknown"
+}
+
+type Segment int
+
+const (
+	SegmentCityHall       Segment = 1
+	SegmentSanitation     Segment = 2
+	SegmentEnergyAndGas   Segment = 3
+	SegmentTelecom        Segment = 4
+	SegmentGovernment     Segment = 5
+	SegmentCNPJIdentified Segment = 6
+	SegmentTrafficFines   Segment = 7
+	SegmentBankExclusive  Segment = 9
+)
+
+func (s Segment) String() string {
+	switch s {
+	case SegmentCityHall:
+		return "prefeituras"
+	case SegmentSanitation:
+		return "saneamento"
+	case SegmentEnergyAndGas:
+		return "energia elétrica e gás"
+	case SegmentTelecom:
+		return "telecomunicações"
+	case SegmentGovernment:
+		return "órgãos governamentais"
+	case SegmentCNPJIdentified:
+		return "carnês e assemelhados"
+	case SegmentTrafficFines:
+		return "multas de trânsito"
+	case SegmentBankExclusive:
+		return "uso exclusivo do banco"
+	}
+	return "desconhecido"
+}
+
+type Boleto struct {
+	Kind          Kind
+	Barcode       string
+	DigitableLine string
+
+	BankCode  string
+	Currency  string
+	DueFactor int
+	FreeField string
+
+	Segment   Segment
+	ValueType int
+	CompanyID string
+
+	AmountCents int64
+	HasAmount   bool
+}
+
+func Parse(code string) (*Boleto, error) {
+	digits, err := normalize(code)
+	if err != nil {
+		return nil, err
+	}
+
+	var barcode, line string
+	switch len(digits) {
+	case 44:
+		barcode = digits
+		line, err = BarcodeToDigitableLine(barcode)
+	case 47, 48:
+		line = digits
+		barcode, err = DigitableLineToBarcode(line)
+	default:
+		return nil, fmt.Errorf("%w: got %d digits", ErrInvalidLength, len(digits))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if barcode[0] == '8' {
+		return parseCollection(barcode, line)
+	}
+	return parseBank(barcode, line), nil
+}
+
+func Validate(code string) error {
+	_, err := Parse(code)
+	return err
+}
+
+func BarcodeToDigitableLine(barcode string) (string, error) {
+	barcode, err := normalize(barcode)
+	if err != nil {
+		return "", err
+	}
+	if len(barcode) != 44 {
+		return "", fmt.Errorf("%w: barcode must have 44 digits, got %d", ErrInvalidLength, len(barcode))
+	}
+	if err := validateBarcode(barcode); err != nil {
+		return "", err
+	}
+
+	if barcode[0] == '8' {
+		dv := collectionCheckDigit(barcode[2])
+		var line strings.Builder
+		for i := 0; i < 4; i++ {
+			block := barcode[i*11 : i*11+11]
+			line.WriteString(block)
+			line.WriteString(strconv.Itoa(dv(block)))
+		}
+		return line.String(), nil
+	}
+
+	free := barcode[19:44]
+	field1 := barcode[0:4] + free[0:5]
+	field2 := free[5:15]
+	field3 := free[15:25]
+
+	return field1 + strconv.Itoa(mod10(field1)) +
+		field2 + strconv.Itoa(mod10(field2)) +
+		field3 + strconv.Itoa(mod10(field3)) +
+		barcode[4:5] + barcode[5:19], nil
+}
+
+func DigitableLineToBarcode(line string) (string, error) {
+	line, err := normalize(line)
+	if err != nil {
+		return "", err
+	}
+
+	switch len(line) {
+	case 47:
+		fields := []string{line[0:9], line[10:20], line[21:31]}
+		checks := []byte{line[9], line[20], line[31]}
+		for i, field := range fields {
+			if strconv.Itoa(mod10(field)) != string(checks[i]) {
+				return "", fmt.Errorf("%w: field %d of the digitable line", ErrInvalidCheckDigit, i+1)
+			}
+		}
+
+		barcode := line[0:4] + line[32:33] + line[33:47] + line[4:9] + line[10:20] + line[21:31]
+		if err := validateBarcode(barcode); err != nil {
+			return "", err
+		}
+		return barcode, nil
+	case 48:
+		if line[0] != '8' {
+			return "", fmt.Errorf("%w: 48-digit lines must start with 8", ErrInvalidLength)
+		}
+
+		dv := collectionCheckDigit(line[2])
+		var barcode strings.Builder
+		for i := 0; i < 4; i++ {
+			block := line[i*12 : i*12+11]
+			if strconv.Itoa(dv(block)) != string(line[i*12+11]) {
+				return "", fmt.Errorf("%w: block %d of the digitable line", ErrInvalidCheckDigit, i+1)
+			}
+			barcode.WriteString(block)
+		}
+
+		if err := validateBarcode(barcode.String()); err != nil {
+			return "", err
+		}
+		return barcode.String(), nil
+	}
+
+	return "", fmt.Errorf("%w: digitable line must have 47 or 48 digits, got %d", ErrInvalidLength, len(line))
+}
+
+func (b *Boleto) Amount() string {
+	return fmt.Sprintf("%d.%02d", b.AmountCents/100, b.AmountCents%100)
+}
+
+func (b *Boleto) AmountFloat() float64 {
+	return float64(b.AmountCents) / 100
+}
+
+func (b *Boleto) DueDate(reference time.Time) (time.Time, bool) {
+	if b.Kind != KindBank || b.DueFactor == 0 {
+		return time.Time{}, false
+	}
+	return DueDateFromFactor(b.DueFactor, reference), true
+}
+
+func validateBarcode(barcode string) error {
+	if barcode[0] == '8' {
+		if barcode[1] < '1' || barcode[1] > '9' || barcode[1] == '8' {
+			return fmt.Errorf("invalid collection segment %c", barcode[1])
+		}
+		if barcode[2] < '6' || barcode[2] > '9' {
+			return fmt.Errorf("invalid collection value identifier %c", barcode[2])
+		}
+
+		dv := collectionCheckDigit(barcode[2])(barcode[0:3] + barcode[4:44])
+		if strconv.Itoa(dv) != barcode[3:4] {
+			return fmt.Errorf("%w: barcode general check digit", ErrInvalidCheckDigit)
+		}
+		return nil
+	}
+
+	if strconv.Itoa(mod11Bank(barcode[0:4]+barcode[5:44])) != barcode[4:5] {
+		return fmt.Errorf("%w: barcode general check digit", ErrInvalidCheckDigit)
+	}
+	return nil
+}
+
+func parseBank(barcode, line string) *Boleto {
+	factor, _ := strconv.Atoi(barcode[5:9])
+	amount, _ := strconv.ParseInt(barcode[9:19], 10, 64)
+
+	return &Boleto{
+		Kind:          KindBank,
+		Barcode:       barcode,
+		DigitableLine: line,
+		BankCode:      barcode[0:3],
+		Currency:      barcode[3:4],
+		DueFactor:     factor,
+		FreeField:     barcode[19:44],
+		AmountCents:   amount,
+		HasAmount:     amount > 0,
+	}
+}
+
+func parseCollection(barcode, line string) (*Boleto, error) {
+	valueType := int(barcode[2] - '0')
+	amount, _ := strconv.ParseInt(barcode[4:15], 10, 64)
+
+	segment := Segment(barcode[1] - '0')
+	companyID := barcode[15:19]
+	if segment == SegmentCNPJIdentified {
+		companyID = barcode[15:23]
+	}
+
+	isCurrency := valueType == 6 || valueType == 8
+
+	return &Boleto{
+		Kind:          KindCollection,
+		Barcode:       barcode,
+		DigitableLine: line,
+		Segment:       segment,
+		ValueType:     valueType,
+		CompanyID:     companyID,
+		FreeField:     barcode[15:44],
+		AmountCents:   amount,
+		HasAmount:     isCurrency && amount > 0,
+	}, nil
+}
+
+func collectionCheckDigit(valueType byte) func(string) int {
+	if valueType == '8' || valueType == '9' {
+		return mod11Collection
+	}
+	return mod10
+}
+
+func normalize(code string) (string, error) {
+	var digits strings.Builder
+	for _, r := range code {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ' || r == '.' || r == '-':
+		default:
+			return "", ErrInvalidCharacters
+		}
+	}
+	return digits.String(), nil
+}
@@ -0,0 +1,109 @@
+package boleto
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseBankBoleto(t *testing.T) {
+	barcode := "36491100000000123450000000001234567890123456"
+	line := "36490.00001 00001.234566 78901.234563 1 10000000012345"
+
+	fromLine, err := Parse(line)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fromLine.Barcode != barcode {
+		t.Errorf("Expected barcode %s, got %s", barcode, fromLine.Barcode)
+	}
+
+	fromBarcode, err := Parse(barcode)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fromBarcode.DigitableLine != "36490000010000123456678901234563110000000012345" {
+		t.Errorf("Unexpected digitable line %s", fromBarcode.DigitableLine)
+	}
+
+	if fromLine.Kind != KindBank || fromLine.BankCode != "364" || fromLine.Currency != "9" {
+		t.Errorf("Unexpected bank fields %+v", fromLine)
+	}
+	if fromLine.Amount() != "123.45" {
+		t.Errorf("Expected amount 123.45, got %s", fromLine.Amount())
+	}
+
+	due, ok := fromLine.DueDate(time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC))
+	if !ok || due.Format("2006-01-02") != "2025-02-22" {
+		t.Errorf("Expected due date 2025-02-22, got %s", due.Format("2006-01-02"))
+	}
+}
+
+func TestParseCollectionBoleto(t *testing.T) {
+	tests := []struct {
+		line    string
+		barcode string
+	}{
+		{"836800000017599001231233456789012345567890123456", "83680000001599001231234567890123456789012345"},
+		{"838600000018599001231236456789012341567890123457", "83860000001599001231234567890123456789012345"},
+	}
+
+	for _, tt := range tests {
+		b, err := Parse(tt.line)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", tt.line, err)
+		}
+		if b.Barcode != tt.barcode {
+			t.Errorf("Expected barcode %s, got %s", tt.barcode, b.Barcode)
+		}
+		if b.Kind != KindCollection || b.Segment != SegmentEnergyAndGas || b.CompanyID != "0123" {
+			t.Errorf("Unexpected collection fields %+v", b)
+		}
+		if !b.HasAmount || b.Amount() != "159.90" {
+			t.Errorf("Expected amount 159.90, got %s", b.Amount())
+		}
+
+		line, err := BarcodeToDigitableLine(tt.barcode)
+		if err != nil || line != tt.line {
+			t.Errorf("Expected line %s, got %s (%v)", tt.line, line, err)
+		}
+	}
+}
+
+func TestParseRejectsTypos(t *testing.T) {
+	codes := []string{
+		"36490.00001 00001.234566 78901.234563 1 10000000012346",
+		"36490.00002 00001.234566 78901.234563 1 10000000012345",
+		"836800000017599001231233456789012345567890123457",
+	}
+
+	for _, code := range codes {
+		if err := Validate(code); !errors.Is(err, ErrInvalidCheckDigit) {
+			t.Errorf("Expected check digit error for %s, got %v", code, err)
+		}
+	}
+
+	if err := Validate("1234"); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Expected length error, got %v", err)
+	}
+}
+
+func TestDueDateFactorRollover(t *testing.T) {
+	reference := time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC)
+	if got := DueDateFromFactor(9999, reference).Format("2006-01-02"); got != "2025-02-21" {
+		t.Errorf("Expected 2025-02-21, got %s", got)
+	}
+	if got := DueDateFromFactor(1000, reference).Format("2006-01-02"); got != "2025-02-22" {
+		t.Errorf("Expected 2025-02-22, got %s", got)
+	}
+	if got := DueDateFromFactor(1000, time.Date(2000, time.July, 1, 0, 0, 0, 0, time.UTC)).Format("2006-01-02"); got != "2000-07-03" {
+		t.Errorf("Expected 2000-07-03, got %s", got)
+	}
+
+	for _, date := range []string{"2000-07-03", "2025-02-21", "2025-02-22", "2026-10-19"} {
+		due, _ := time.Parse("2006-01-02", date)
+		if got := DueDateFromFactor(FactorFromDueDate(due), due).Format("2006-01-02"); got != date {
+			t.Errorf("Expected round trip of %s, got %s", date, got)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package boleto
+
+func mod10(digits string) int {
+	sum := 0
+	weight := 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		product := int(digits[i]-'0') * weight
+		if product > 9 {
+			product = product/10 + product%10
+		}
+		sum += product
+		if weight == 2 {
+			weight = 1
+		} else {
+			weight = 2
+		}
+	}
+
+	return (10 - sum%10) % 10
+}
+
+func mod11Weights(digits string) int {
+	sum := 0
+	weight := 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		sum += int(digits[i]-'0') * weight
+		weight++
+		if weight > 9 {
+			weight = 2
+		}
+	}
+	return sum
+}
+
+func mod11Bank(digits string) int {
+	dv := 11 - mod11Weights(digits)%11
+	if dv == 0 || dv == 10 || dv == 11 {
+		return 1
+	}
+	return dv
+}
+
+func mod11Collection(digits string) int {
+	rest := mod11Weights(digits) % 11
+	if rest == 0 || rest == 1 {
+		return 0
+	}
+	return 11 - rest
+}
@@ -0,0 +1,53 @@
+package boleto
+
+import "time"
+
+var (
+	factorBaseDate     = time.Date(1997, time.October, 7, 0, 0, 0, 0, time.UTC)
+	factorRolloverDate = time.Date(2025, time.February, 22, 0, 0, 0, 0, time.UTC)
+)
+
+const (
+	factorMin   = 1000
+	factorMax   = 9999
+	factorCycle = factorMax - factorMin + 1
+)
+
+func DueDateFromFactor(factor int, reference time.Time) time.Time {
+	if factor < factorMin {
+		return factorBaseDate.AddDate(0, 0, factor)
+	}
+
+	ref := time.Date(reference.Year(), reference.Month(), reference.Day(), 0, 0, 0, 0, time.UTC)
+
+	candidate := factorRolloverDate.AddDate(0, 0, factor-factorMin)
+	if candidate.After(ref) {
+		for {
+			previous := candidate.AddDate(0, 0, -factorCycle)
+			if previous.Before(factorBaseDate) || ref.Sub(previous) > candidate.Sub(ref) {
+				break
+			}
+			candidate = previous
+		}
+		return candidate
+	}
+
+	for {
+		next := candidate.AddDate(0, 0, factorCycle)
+		if next.Sub(ref) >= ref.Sub(candidate) {
+			return candidate
+		}
+		candidate = next
+	}
+}
+
+func FactorFromDueDate(dueDate time.Time) int {
+	date := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, time.UTC)
+
+	days := int(date.Sub(factorBaseDate).Hours() / 24)
+	if days < factorMin {
+		return days
+	}
+
+	return (days-factorMin)%factorCycle + factorMin
+}
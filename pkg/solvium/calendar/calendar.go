@@ -0,0 +1,193 @@
+package calendar
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type RollPolicy int
+
+const (
+	RollNone RollPolicy = iota
+	RollFollowing
+	RollPreceding
+	RollModifiedFollowing
+)
+
+type Calendar struct {
+	providers []HolidayProvider
+
+	mu    sync.Mutex
+	years map[int]map[time.Time]Holiday
+}
+
+func New(providers ...HolidayProvider) *Calendar {
+	return &Calendar{
+		providers: append([]HolidayProvider{BankingHolidays}, providers...),
+		years:     make(map[int]map[time.Time]Holiday),
+	}
+}
+
+func (c *Calendar) With(providers ...HolidayProvider) *Calendar {
+	combined := make([]HolidayProvider, 0, len(c.providers)+len(providers))
+	combined = append(combined, c.providers...)
+	combined = append(combined, providers...)
+
+	return &Calendar{
+		providers: combined,
+		years:     make(map[int]map[time.Time]Holiday),
+	}
+}
+
+func (c *Calendar) Holiday(t time.Time) (Holiday, bool) {
+	day := truncate(t)
+	holiday, ok := c.holidays(day.Year())[day]
+	return holiday, ok
+}
+
+func (c *Calendar) Holidays(year int) []Holiday {
+	index := c.holidays(year)
+
+	holidays := make([]Holiday, 0, len(index))
+	for day := date(year, time.January, 1); day.Year() == year; day = day.AddDate(0, 0, 1) {
+		if holiday, ok := index[day]; ok {
+			holidays = append(holidays, holiday)
+		}
+	}
+	return holidays
+}
+
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	switch t.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+
+	_, holiday := c.Holiday(t)
+	return !holiday
+}
+
+func (c *Calendar) NextBusinessDay(t time.Time) time.Time {
+	day := startOfDay(t).AddDate(0, 0, 1)
+	for !c.IsBusinessDay(day) {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day
+}
+
+func (c *Calendar) PreviousBusinessDay(t time.Time) time.Time {
+	day := startOfDay(t).AddDate(0, 0, -1)
+	for !c.IsBusinessDay(day) {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+func (c *Calendar) AddBusinessDays(t time.Time, n int) time.Time {
+	day := startOfDay(t)
+	for ; n > 0; n-- {
+		day = c.NextBusinessDay(day)
+	}
+	for ; n < 0; n++ {
+		day = c.PreviousBusinessDay(day)
+	}
+	return day
+}
+
+func (c *Calendar) BusinessDaysBetween(start, end time.Time) int {
+	sign := 1
+	from, to := startOfDay(start), startOfDay(end)
+	if to.Before(from) {
+		from, to = to, from
+		sign = -1
+	}
+
+	count := 0
+	for day := from.AddDate(0, 0, 1); !day.After(to); day = day.AddDate(0, 0, 1) {
+		if c.IsBusinessDay(day) {
+			count++
+		}
+	}
+	return count * sign
+}
+
+func (c *Calendar) Adjust(t time.Time, policy RollPolicy) time.Time {
+	day := startOfDay(t)
+	if policy == RollNone || c.IsBusinessDay(day) {
+		return day
+	}
+
+	switch policy {
+	case RollFollowing:
+		return c.NextBusinessDay(day)
+	case RollPreceding:
+		return c.PreviousBusinessDay(day)
+	case RollModifiedFollowing:
+		next := c.NextBusinessDay(day)
+		if next.Month() != day.Month() {
+			return c.PreviousBusinessDay(day)
+		}
+		return next
+	}
+
+	return day
+}
+
+func (c *Calendar) holidays(year int) map[time.Time]Holiday {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if index, ok := c.years[year]; ok {
+		return index
+	}
+
+	index := make(map[time.Time]Holiday)
+	for _, provider := range c.providers {
+		for _, holiday := range provider.Holidays(year) {
+			day := truncate(holiday.Date)
+			if _, exists := index[day]; !exists {
+				index[day] = Holiday{Date: day, Name: holiday.Name}
+			}
+		}
+	}
+
+	c.years[year] = index
+	return index
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+type Scheduler struct {
+	Calendar *Calendar
+	Policy   RollPolicy
+}
+
+func NewScheduler(calendar *Calendar, policy RollPolicy) *Scheduler {
+	if calendar == nil {
+		calendar = New()
+	}
+	return &Scheduler{
+		Calendar: calendar,
+		Policy:   policy,
+	}
+}
+
+func (s *Scheduler) Adjust(t time.Time) time.Time {
+	return s.Calendar.Adjust(t, s.Policy)
+}
+
+func (s *Scheduler) AdjustDate(value string) (string, error) {
+	if value == "" {
+		return value, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return "", fmt.Errorf("invalid date %q: %w", value, err)
+	}
+
+	return s.Adjust(t).Format("2006-01-02"), nil
+}
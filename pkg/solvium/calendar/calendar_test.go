@@ -0,0 +1,62 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+func day(value string) time.Time {
+	t, _ := time.Parse("2006-01-02", value)
+	return t
+}
+
+func TestMovableHolidays(t *testing.T) {
+	cal := New()
+
+	for _, value := range []string{"2025-03-03", "2025-03-04", "2025-04-18", "2025-06-19", "2024-11-20"} {
+		if _, ok := cal.Holiday(day(value)); !ok {
+			t.Errorf("Expected %s to be a holiday", value)
+		}
+	}
+
+	if got := Easter(2025).Format("2006-01-02"); got != "2025-04-20" {
+		t.Errorf("Expected Easter on 2025-04-20, got %s", got)
+	}
+	if _, ok := cal.Holiday(day("2023-11-20")); ok {
+		t.Errorf("Expected 2023-11-20 not to be a national holiday")
+	}
+}
+
+func TestBusinessDayNavigation(t *testing.T) {
+	cal := New(FixedHolidays{{Month: time.January, Day: 25, Name: "Aniversário de São Paulo"}})
+
+	if got := cal.NextBusinessDay(day("2025-02-28")).Format("2006-01-02"); got != "2025-03-05" {
+		t.Errorf("Expected 2025-03-05 after Carnival, got %s", got)
+	}
+	if got := cal.PreviousBusinessDay(day("2025-04-21")).Format("2006-01-02"); got != "2025-04-17" {
+		t.Errorf("Expected 2025-04-17 before Easter, got %s", got)
+	}
+	if cal.IsBusinessDay(day("2027-01-25")) {
+		t.Errorf("Expected municipal holiday to be a non-business day")
+	}
+	if got := cal.BusinessDaysBetween(day("2025-12-19"), day("2026-01-05")); got != 8 {
+		t.Errorf("Expected 8 business days, got %d", got)
+	}
+}
+
+func TestSchedulerRollsForward(t *testing.T) {
+	scheduler := NewScheduler(nil, RollFollowing)
+
+	got, err := scheduler.AdjustDate("2025-12-25")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got != "2025-12-26" {
+		t.Errorf("Expected 2025-12-26, got %s", got)
+	}
+
+	modified := NewScheduler(nil, RollModifiedFollowing)
+	if got := modified.Adjust(day("2025-05-31")).Format("2006-01-02"); got != "2025-05-30" {
+		t.Errorf("Expected 2025-05-30, got %s", got)
+	}
+}
@@ -0,0 +1,113 @@
+package calendar
+
+import "time"
+
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+type HolidayProvider interface {
+	Holidays(year int) []Holiday
+}
+
+type HolidayFunc func(year int) []Holiday
+
+func (f HolidayFunc) Holidays(year int) []Holiday {
+	return f(year)
+}
+
+type FixedHoliday struct {
+	Month time.Month
+	Day   int
+	Name  string
+}
+
+type FixedHolidays []FixedHoliday
+
+func (f FixedHolidays) Holidays(year int) []Holiday {
+	holidays := make([]Holiday, 0, len(f))
+	for _, fixed := range f {
+		holidays = append(holidays, Holiday{Date: date(year, fixed.Month, fixed.Day), Name: fixed.Name})
+	}
+	return holidays
+}
+
+type DateHolidays []Holiday
+
+func (d DateHolidays) Holidays(year int) []Holiday {
+	var holidays []Holiday
+	for _, holiday := range d {
+		if holiday.Date.Year() == year {
+			holidays = append(holidays, Holiday{Date: truncate(holiday.Date), Name: holiday.Name})
+		}
+	}
+	return holidays
+}
+
+type nationalHolidays struct {
+	banking bool
+}
+
+var (
+	NationalHolidays HolidayProvider = nationalHolidays{}
+	BankingHolidays  HolidayProvider = nationalHolidays{banking: true}
+)
+
+func (n nationalHolidays) Holidays(year int) []Holiday {
+	easter := Easter(year)
+
+	holidays := []Holiday{
+		{Date: date(year, time.January, 1), Name: "Confraternização Universal"},
+		{Date: easter.AddDate(0, 0, -2), Name: "Paixão de Cristo"},
+		{Date: date(year, time.April, 21), Name: "Tiradentes"},
+		{Date: date(year, time.May, 1), Name: "Dia do Trabalho"},
+		{Date: date(year, time.September, 7), Name: "Independência do Brasil"},
+		{Date: date(year, time.October, 12), Name: "Nossa Senhora Aparecida"},
+		{Date: date(year, time.November, 2), Name: "Finados"},
+		{Date: date(year, time.November, 15), Name: "Proclamação da República"},
+		{Date: date(year, time.December, 25), Name: "Natal"},
+	}
+
+	if year >= 2024 {
+		holidays = append(holidays, Holiday{Date: date(year, time.November, 20), Name: "Dia Nacional de Zumbi e da Consciência Negra"})
+	}
+
+	if n.banking {
+		holidays = append(holidays,
+			Holiday{Date: easter.AddDate(0, 0, -48), Name: "Carnaval"},
+			Holiday{Date: easter.AddDate(0, 0, -47), Name: "Carnaval"},
+			Holiday{Date: easter.AddDate(0, 0, 60), Name: "Corpus Christi"},
+			Holiday{Date: date(year, time.December, 31), Name: "Último dia do ano sem expediente bancário"},
+		)
+	}
+
+	return holidays
+}
+
+func Easter(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return date(year, time.Month(month), day)
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func truncate(t time.Time) time.Time {
+	return date(t.Year(), t.Month(), t.Day())
+}
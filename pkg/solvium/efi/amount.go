@@ -0,0 +1,64 @@
+package efi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func ParseAmount(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("empty amount")
+	}
+
+	negative := false
+	if strings.HasPrefix(value, "-") {
+		negative = true
+		value = value[1:]
+	} else if strings.HasPrefix(value, "+") {
+		value = value[1:]
+	}
+
+	value = strings.Replace(value, ",", ".", 1)
+
+	whole, fraction := value, ""
+	if i := strings.IndexByte(value, '.'); i >= 0 {
+		whole, fraction = value[:i], value[i+1:]
+	}
+
+	if len(fraction) > 2 {
+		return 0, fmt.Errorf("invalid amount %q: more than two decimal places", value)
+	}
+	for len(fraction) < 2 {
+		fraction += "0"
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", value, err)
+	}
+	cents, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", value, err)
+	}
+
+	total := units*100 + cents
+	if negative {
+		total = -total
+	}
+
+	return total, nil
+}
+
+func FormatAmount(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
@@ -2,6 +2,7 @@ package efi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -24,6 +25,17 @@ func NewBatchDueCharges(client *Client) *BatchDueCharges {
 
 
 func (b *BatchDueCharges) CreateOrUpdate(id string, request BatchDueChargesRequest) (*BatchDueChargesResponse, error) {
+	cobsV := make([]CreateDueChargeRequest, len(request.CobsV))
+	for i, cobv := range request.CobsV {
+		dueDate, err := b.client.adjustScheduledDate(cobv.Calendario.DataDeVencimento)
+		if err != nil {
+			return nil, fmt.Errorf("failed to adjust due date of %s: %w", cobv.TxID, err)
+		}
+		cobv.Calendario.DataDeVencimento = dueDate
+		cobsV[i] = cobv
+	}
+	request.CobsV = cobsV
+
 	bodyBytes, err := json.Marshal(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -51,6 +63,17 @@ func (b *BatchDueCharges) CreateOrUpdate(id string, request BatchDueChargesReque
 
 
 func (b *BatchDueCharges) ReviewBatch(id string, request BatchDueChargesReviewRequest) (*BatchDueChargesResponse, error) {
+	cobsV := make([]BatchDueChargesReviewItem, len(request.CobsV))
+	for i, cobv := range request.CobsV {
+		dueDate, err := b.client.adjustScheduledDate(cobv.Calendario.DataDeVencimento)
+		if err != nil {
+			return nil, fmt.Errorf("failed to adjust due date of %s: %w", cobv.TxID, err)
+		}
+		cobv.Calendario.DataDeVencimento = dueDate
+		cobsV[i] = cobv
+	}
+	request.CobsV = cobsV
+
 	bodyBytes, err := json.Marshal(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -131,3 +154,22 @@ func (b *BatchDueCharges) List(startDate, endDate time.Time, options *ListBatchD
 
 	return &listResp, nil
 }
+
+func (b *BatchDueCharges) Iterate(startDate, endDate time.Time, options *ListBatchDueChargesOptions, pageOptions *PageOptions) *Iterator[BatchDueChargesResponse] {
+	return newPageIterator(pageOptions, func(ctx context.Context, page, pageSize int) ([]BatchDueChargesResponse, int, error) {
+		pageFilter := ListBatchDueChargesOptions{}
+		if options != nil {
+			pageFilter = *options
+		}
+		pageFilter.PaginaAtual = page
+		if pageSize > 0 {
+			pageFilter.ItensPorPagina = pageSize
+		}
+
+		list, err := b.List(startDate, endDate, &pageFilter)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Lotes, list.Parametros.Paginacao.QuantidadeDePaginas, nil
+	})
+}
@@ -0,0 +1,372 @@
+package efi
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const MaxBatchDueCharges = 1000
+
+const (
+	BatchItemStatusProcessing = "EM_PROCESSAMENTO"
+	BatchItemStatusCreated    = "CRIADA"
+	BatchItemStatusDenied     = "NEGADA"
+	BatchItemStatusInvalid    = "INVALIDA"
+)
+
+const (
+	CSVFieldReference     = "referencia"
+	CSVFieldTxID          = "txid"
+	CSVFieldDueDate       = "dataDeVencimento"
+	CSVFieldValidity      = "validadeAposVencimento"
+	CSVFieldDebtorName    = "devedor.nome"
+	CSVFieldDebtorCPF     = "devedor.cpf"
+	CSVFieldDebtorCNPJ    = "devedor.cnpj"
+	CSVFieldDebtorEmail   = "devedor.email"
+	CSVFieldDebtorStreet  = "devedor.logradouro"
+	CSVFieldDebtorCity    = "devedor.cidade"
+	CSVFieldDebtorState   = "devedor.uf"
+	CSVFieldDebtorZip     = "devedor.cep"
+	CSVFieldAmount        = "valor.original"
+	CSVFieldFineMode      = "multa.modalidade"
+	CSVFieldFineValue     = "multa.valorPerc"
+	CSVFieldInterestMode  = "juros.modalidade"
+	CSVFieldInterestValue = "juros.valorPerc"
+	CSVFieldDiscountMode  = "desconto.modalidade"
+	CSVFieldDiscountValue = "desconto.valorPerc"
+	CSVFieldRebateMode    = "abatimento.modalidade"
+	CSVFieldRebateValue   = "abatimento.valorPerc"
+	CSVFieldKey           = "chave"
+	CSVFieldPayerRequest  = "solicitacaoPagador"
+)
+
+var chargeTxIDPattern = regexp.MustCompile(`^[a-zA-Z0-9]{26,35}$`)
+
+type DueChargeImportRow struct {
+	Line       int
+	Reference  string
+	TxID       string
+	Request    *CreateDueChargeRequest
+	Errors     []string
+	BatchID    string
+	Status     string
+	Violations []Violacao
+}
+
+func (r *DueChargeImportRow) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+type DueChargeCSVImporter struct {
+	batches *BatchDueCharges
+
+	Mapping       map[string]string
+	DefaultKey    string
+	TxIDNamespace string
+	Description   string
+	BatchSize     int
+	PollInterval  time.Duration
+	NextBatchID   func() string
+}
+
+func NewDueChargeCSVImporter(batches *BatchDueCharges) *DueChargeCSVImporter {
+	return &DueChargeCSVImporter{
+		batches:      batches,
+		Mapping:      make(map[string]string),
+		BatchSize:    MaxBatchDueCharges,
+		PollInterval: 2 * time.Second,
+		NextBatchID: func() string {
+			return strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10)
+		},
+	}
+}
+
+func (i *DueChargeCSVImporter) Import(ctx context.Context, in io.Reader, out io.Writer) ([]*DueChargeImportRow, error) {
+	rows, err := i.Parse(in)
+	if err != nil {
+		return nil, err
+	}
+
+	submitErr := i.Submit(ctx, rows)
+
+	if out != nil {
+		if err := WriteDueChargeImportResults(out, rows); err != nil {
+			return rows, err
+		}
+	}
+
+	return rows, submitErr
+}
+
+func (i *DueChargeCSVImporter) Parse(in io.Reader) ([]*DueChargeImportRow, error) {
+	reader := csv.NewReader(in)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for index, name := range header {
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = index
+	}
+
+	var rows []*DueChargeImportRow
+	seen := make(map[string]int)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV line %d: %w", line, err)
+		}
+
+		row := i.parseRow(line, columns, record)
+		if row.TxID != "" {
+			if first, ok := seen[row.TxID]; ok {
+				row.Errors = append(row.Errors, fmt.Sprintf("txid duplicates line %d", first))
+			} else {
+				seen[row.TxID] = line
+			}
+		}
+		if !row.Valid() {
+			row.Status = BatchItemStatusInvalid
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func (i *DueChargeCSVImporter) Submit(ctx context.Context, rows []*DueChargeImportRow) error {
+	var valid []*DueChargeImportRow
+	for _, row := range rows {
+		if row.Valid() {
+			valid = append(valid, row)
+		}
+	}
+
+	size := i.BatchSize
+	if size <= 0 || size > MaxBatchDueCharges {
+		size = MaxBatchDueCharges
+	}
+
+	for start := 0; start < len(valid); start += size {
+		end := start + size
+		if end > len(valid) {
+			end = len(valid)
+		}
+
+		if err := i.submitBatch(ctx, valid[start:end]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (i *DueChargeCSVImporter) submitBatch(ctx context.Context, rows []*DueChargeImportRow) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	batchID := i.NextBatchID()
+	request := BatchDueChargesRequest{Descricao: i.Description}
+	byTxID := make(map[string]*DueChargeImportRow, len(rows))
+	for _, row := range rows {
+		row.BatchID = batchID
+		row.Status = BatchItemStatusProcessing
+		request.CobsV = append(request.CobsV, *row.Request)
+		byTxID[row.TxID] = row
+	}
+
+	if _, err := i.batches.CreateOrUpdate(batchID, request); err != nil {
+		for _, row := range rows {
+			row.Status = BatchItemStatusDenied
+			row.Errors = append(row.Errors, err.Error())
+		}
+		return fmt.Errorf("failed to submit batch %s: %w", batchID, err)
+	}
+
+	for {
+		batch, err := i.batches.GetByID(batchID)
+		if err != nil {
+			return err
+		}
+
+		pending := 0
+		for _, item := range batch.CobsV {
+			row, ok := byTxID[item.TxID]
+			if !ok {
+				continue
+			}
+
+			row.Status = item.Status
+			if item.Problema != nil {
+				row.Violations = item.Problema.Violacoes
+				if len(item.Problema.Violacoes) == 0 && item.Problema.Detail != "" {
+					row.Violations = []Violacao{{Razao: item.Problema.Detail}}
+				}
+			}
+			if item.Status == BatchItemStatusProcessing {
+				pending++
+			}
+		}
+
+		if pending == 0 && len(batch.CobsV) > 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(i.PollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (i *DueChargeCSVImporter) parseRow(line int, columns map[string]int, record []string) *DueChargeImportRow {
+	row := &DueChargeImportRow{Line: line}
+	value := func(field string) string {
+		column := field
+		if mapped, ok := i.Mapping[field]; ok {
+			column = mapped
+		}
+		index, ok := columns[column]
+		if !ok || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+	fail := func(format string, args ...interface{}) {
+		row.Errors = append(row.Errors, fmt.Sprintf(format, args...))
+	}
+	intValue := func(field string) int {
+		raw := value(field)
+		if raw == "" {
+			return 0
+		}
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			fail("%s must be an integer", field)
+		}
+		return parsed
+	}
+
+	row.Reference = value(CSVFieldReference)
+	row.TxID = value(CSVFieldTxID)
+	if row.TxID == "" && row.Reference != "" {
+		row.TxID = TxIDForOrder(i.TxIDNamespace, row.Reference)
+	}
+	if !chargeTxIDPattern.MatchString(row.TxID) {
+		fail("txid must have 26 to 35 alphanumeric characters")
+	}
+
+	request := &CreateDueChargeRequest{
+		TxID: row.TxID,
+		Calendario: CalendarioDueCharge{
+			DataDeVencimento:       value(CSVFieldDueDate),
+			ValidadeAposVencimento: intValue(CSVFieldValidity),
+		},
+		Devedor: DevedorDueCharge{
+			EnderecoDevedor: EnderecoDevedor{
+				Logradouro: value(CSVFieldDebtorStreet),
+				Cidade:     value(CSVFieldDebtorCity),
+				UF:         strings.ToUpper(value(CSVFieldDebtorState)),
+				CEP:        onlyDigits(value(CSVFieldDebtorZip)),
+			},
+			CPF:   onlyDigits(value(CSVFieldDebtorCPF)),
+			CNPJ:  onlyDigits(value(CSVFieldDebtorCNPJ)),
+			Nome:  value(CSVFieldDebtorName),
+			Email: value(CSVFieldDebtorEmail),
+		},
+		Valor: ValorDueCharge{
+			Original:   value(CSVFieldAmount),
+			Multa:      Multa{Modalidade: intValue(CSVFieldFineMode), ValorPerc: value(CSVFieldFineValue)},
+			Juros:      Juros{Modalidade: intValue(CSVFieldInterestMode), ValorPerc: value(CSVFieldInterestValue)},
+			Desconto:   Desconto{Modalidade: intValue(CSVFieldDiscountMode), ValorPerc: value(CSVFieldDiscountValue)},
+			Abatimento: Abatimento{Modalidade: intValue(CSVFieldRebateMode), ValorPerc: value(CSVFieldRebateValue)},
+		},
+		Chave:              firstNonEmpty(value(CSVFieldKey), i.DefaultKey),
+		SolicitacaoPagador: value(CSVFieldPayerRequest),
+	}
+
+	if _, err := time.Parse("2006-01-02", request.Calendario.DataDeVencimento); err != nil {
+		fail("dataDeVencimento must use the YYYY-MM-DD format")
+	}
+
+	if amount, err := ParseAmount(request.Valor.Original); err != nil || amount <= 0 {
+		fail("valor.original must be a positive amount")
+	} else {
+		request.Valor.Original = FormatAmount(amount)
+	}
+
+	switch {
+	case request.Devedor.CPF != "" && request.Devedor.CNPJ != "":
+		fail("devedor must have either cpf or cnpj, not both")
+	case request.Devedor.CPF != "" && len(request.Devedor.CPF) != 11:
+		fail("devedor.cpf must have 11 digits")
+	case request.Devedor.CNPJ != "" && len(request.Devedor.CNPJ) != 14:
+		fail("devedor.cnpj must have 14 digits")
+	case request.Devedor.CPF == "" && request.Devedor.CNPJ == "":
+		fail("devedor.cpf or devedor.cnpj is required")
+	}
+
+	if request.Devedor.Nome == "" {
+		fail("devedor.nome is required")
+	}
+	if request.Chave == "" {
+		fail("chave is required")
+	}
+	if len(request.SolicitacaoPagador) > 140 {
+		fail("solicitacaoPagador must have at most 140 characters")
+	}
+
+	row.Request = request
+	return row
+}
+
+func WriteDueChargeImportResults(w io.Writer, rows []*DueChargeImportRow) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"linha", "referencia", "txid", "lote", "status", "erros"}); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		problems := append([]string(nil), row.Errors...)
+		for _, violation := range row.Violations {
+			if violation.Propriedade != "" {
+				problems = append(problems, fmt.Sprintf("%s: %s", violation.Propriedade, violation.Razao))
+			} else {
+				problems = append(problems, violation.Razao)
+			}
+		}
+
+		record := []string{
+			strconv.Itoa(row.Line),
+			row.Reference,
+			row.TxID,
+			row.BatchID,
+			row.Status,
+			strings.Join(problems, "; "),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,62 @@
+package efi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDueChargeCSVImporterParse(t *testing.T) {
+	input := strings.Join([]string{
+		"referencia,vencimento,nome,cpf,valor",
+		"order-1,2030-01-15,Ana Souza,123.456.789-09,\"150,5\"",
+		"order-2,15/01/2030,Bruno Lima,12345678909,10.00",
+		"order-1,2030-01-15,Ana Souza,123.456.789-09,150.50",
+		"order-3,2030-01-15,,123,0",
+	}, "\n")
+
+	importer := NewDueChargeCSVImporter(NewBatchDueCharges(&Client{}))
+	importer.DefaultKey = "pix@example.com"
+	importer.TxIDNamespace = "test"
+	importer.Mapping[CSVFieldDueDate] = "vencimento"
+	importer.Mapping[CSVFieldDebtorName] = "nome"
+	importer.Mapping[CSVFieldDebtorCPF] = "cpf"
+	importer.Mapping[CSVFieldAmount] = "valor"
+
+	rows, err := importer.Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("expected 4 rows, got %d", len(rows))
+	}
+
+	first := rows[0]
+	if !first.Valid() {
+		t.Fatalf("expected first row to be valid, got %v", first.Errors)
+	}
+	if first.Request.Valor.Original != "150.50" || first.Request.Devedor.CPF != "12345678909" {
+		t.Fatalf("unexpected request: %+v", first.Request)
+	}
+	if first.TxID != TxIDForOrder("test", "order-1") || first.Request.TxID != first.TxID {
+		t.Fatalf("expected deterministic txid, got %s", first.TxID)
+	}
+
+	if rows[1].Valid() {
+		t.Fatal("expected invalid due date to be rejected")
+	}
+	if rows[2].Valid() {
+		t.Fatal("expected duplicate txid to be rejected")
+	}
+	if len(rows[3].Errors) < 3 {
+		t.Fatalf("expected several errors on last row, got %v", rows[3].Errors)
+	}
+
+	var out bytes.Buffer
+	if err := WriteDueChargeImportResults(&out, rows); err != nil {
+		t.Fatalf("unexpected error writing results: %v", err)
+	}
+	if lines := strings.Count(out.String(), "\n"); lines != 5 {
+		t.Fatalf("expected header and 4 result lines, got %d", lines)
+	}
+}
@@ -2,7 +2,9 @@ package efi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -43,9 +45,20 @@ func (b *BillPayment) DetailBarcode(barcode string) (*BillDetails, error) {
 }
 
 func (b *BillPayment) RequestPayment(barcode string, request *BillPaymentRequest) (*BillPaymentResponse, error) {
+	if request == nil {
+		return nil, errors.New("payment request is required")
+	}
+
 	path := fmt.Sprintf("/v1/codBarras/%s", barcode)
 
-	payload, err := json.Marshal(request)
+	paymentDate, err := b.client.adjustScheduledDate(request.PaymentDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to adjust payment date: %w", err)
+	}
+	adjusted := *request
+	adjusted.PaymentDate = paymentDate
+
+	payload, err := json.Marshal(adjusted)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal payment request: %w", err)
 	}
@@ -123,3 +136,101 @@ func (b *BillPayment) GetPaymentSummaryByDateRange(days int) (*BillPaymentSummar
 
 	return b.GetPaymentSummary(startDate, endDate)
 }
+
+func (b *BillPayment) CancelPayment(paymentID string) (*BillPaymentResponse, error) {
+	path := fmt.Sprintf("/v1/%s", paymentID)
+
+	resp, err := b.client.Request(http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel payment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to cancel payment with status %d: %s", resp.StatusCode, body)
+	}
+
+	var paymentResponse BillPaymentResponse
+	if err := json.NewDecoder(resp.Body).Decode(&paymentResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode cancel response: %w", err)
+	}
+
+	return &paymentResponse, nil
+}
+
+func (b *BillPayment) ListPayments(startDate, endDate string, options *ListBillPaymentsOptions) (*BillPaymentListResponse, error) {
+	query := url.Values{}
+	query.Add("dataInicial", startDate)
+	query.Add("dataFinal", endDate)
+
+	if options != nil {
+		if options.Status != "" {
+			query.Add("status", string(options.Status))
+		}
+		if options.PaginaAtual > 0 {
+			query.Add("paginacao.paginaAtual", fmt.Sprintf("%d", options.PaginaAtual))
+		}
+		if options.ItensPorPagina > 0 {
+			query.Add("paginacao.itensPorPagina", fmt.Sprintf("%d", options.ItensPorPagina))
+		}
+	}
+
+	path := fmt.Sprintf("/v1/pagamentos?%s", query.Encode())
+
+	resp, err := b.client.Request(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to list payments with status %d: %s", resp.StatusCode, body)
+	}
+
+	var list BillPaymentListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to decode payment list: %w", err)
+	}
+
+	return &list, nil
+}
+
+func (b *BillPayment) ListPaymentsByDateRange(days int, options *ListBillPaymentsOptions) (*BillPaymentListResponse, error) {
+	endDate := time.Now().Format("2006-01-02")
+	startDate := time.Now().AddDate(0, 0, -days).Format("2006-01-02")
+
+	return b.ListPayments(startDate, endDate, options)
+}
+
+func (b *BillPayment) IteratePayments(startDate, endDate string, status BillPaymentStatus, pageOptions *PageOptions) *Iterator[BillPaymentResponse] {
+	return newPageIterator(pageOptions, func(ctx context.Context, page, pageSize int) ([]BillPaymentResponse, int, error) {
+		list, err := b.ListPayments(startDate, endDate, &ListBillPaymentsOptions{Status: status, PaginaAtual: page, ItensPorPagina: pageSize})
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Payments, list.Parameters.Pagination.TotalPages, nil
+	})
+}
+
+func (b *BillPayment) ListScheduledPayments(ctx context.Context, startDate, endDate string) ([]BillPaymentResponse, error) {
+	return b.IteratePayments(startDate, endDate, BillPaymentStatusUnsettled, nil).All(ctx)
+}
+
+func (b *BillPayment) ExpandFailed(summary *BillPaymentSummary) ([]BillPaymentResponse, error) {
+	if summary == nil {
+		return nil, errors.New("bill payment summary is required")
+	}
+
+	failed := make([]BillPaymentResponse, 0, len(summary.FailedIds))
+	for _, paymentID := range summary.FailedIds {
+		payment, err := b.GetPaymentStatus(paymentID)
+		if err != nil {
+			return failed, fmt.Errorf("failed to expand payment %s: %w", paymentID, err)
+		}
+		failed = append(failed, *payment)
+	}
+
+	return failed, nil
+}
@@ -67,3 +67,20 @@ type BillDetails struct {
 	Fine          float64 `json:"multa,omitempty"`
 	CIP           string  `json:"cip,omitempty"`
 }
+
+type ListBillPaymentsOptions struct {
+	Status         BillPaymentStatus
+	PaginaAtual    int
+	ItensPorPagina int
+}
+
+type BillPaymentListParameters struct {
+	StartDate  string                       `json:"dataInicial"`
+	EndDate    string                       `json:"dataFinal"`
+	Pagination BillPaymentWebhookPagination `json:"paginacao"`
+}
+
+type BillPaymentListResponse struct {
+	Parameters BillPaymentListParameters `json:"parametros"`
+	Payments   []BillPaymentResponse     `json:"pagamentos"`
+}
@@ -0,0 +1,44 @@
+package efi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestListScheduledPaymentsStartsAtFirstPage(t *testing.T) {
+	var pages []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("status") != string(BillPaymentStatusUnsettled) {
+			t.Errorf("unexpected status filter %q", query.Get("status"))
+		}
+		page := query.Get("paginacao.paginaAtual")
+		pages = append(pages, page)
+
+		var list BillPaymentListResponse
+		list.Parameters.Pagination.TotalPages = 2
+		list.Payments = []BillPaymentResponse{{PaymentID: "page-" + page}}
+		json.NewEncoder(w).Encode(list)
+	}))
+
+	payments, err := client.BillPayment().ListScheduledPayments(context.Background(), "2024-01-01", "2024-01-31")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(pages) != 2 || pages[0] != "" || pages[1] != "1" {
+		t.Fatalf("expected pages 0 and 1, got %q", pages)
+	}
+	if len(payments) != 2 || payments[0].PaymentID != "page-" {
+		t.Fatalf("unexpected payments: %+v", payments)
+	}
+}
+
+func TestExpandFailedRequiresSummary(t *testing.T) {
+	client := newTestClient(t, http.NotFoundHandler())
+	if _, err := client.BillPayment().ExpandFailed(nil); err == nil {
+		t.Fatal("expected an error for a nil summary")
+	}
+}
@@ -2,6 +2,7 @@ package efi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -50,10 +51,23 @@ func (w *BillPaymentWebhookClient) Create(webhookURL string) (*BillPaymentWebhoo
 }
 
 func (w *BillPaymentWebhookClient) List(startDate, endDate time.Time) (*BillPaymentWebhookListResponse, error) {
+	return w.ListWithOptions(startDate, endDate, nil)
+}
+
+func (w *BillPaymentWebhookClient) ListWithOptions(startDate, endDate time.Time, options *ListBillPaymentWebhooksOptions) (*BillPaymentWebhookListResponse, error) {
 	query := url.Values{}
 	query.Add("dataInicio", startDate.Format(time.RFC3339))
 	query.Add("dataFim", endDate.Format(time.RFC3339))
 
+	if options != nil {
+		if options.PaginaAtual > 0 {
+			query.Add("paginacao.paginaAtual", fmt.Sprintf("%d", options.PaginaAtual))
+		}
+		if options.ItensPorPagina > 0 {
+			query.Add("paginacao.itensPorPagina", fmt.Sprintf("%d", options.ItensPorPagina))
+		}
+	}
+
 	path := fmt.Sprintf("/v1/webhook?%s", query.Encode())
 
 	resp, err := w.client.Request(http.MethodGet, path, nil)
@@ -75,6 +89,16 @@ func (w *BillPaymentWebhookClient) List(startDate, endDate time.Time) (*BillPaym
 	return &listResponse, nil
 }
 
+func (w *BillPaymentWebhookClient) Iterate(startDate, endDate time.Time, pageOptions *PageOptions) *Iterator[BillPaymentWebhook] {
+	return newPageIterator(pageOptions, func(ctx context.Context, page, pageSize int) ([]BillPaymentWebhook, int, error) {
+		list, err := w.ListWithOptions(startDate, endDate, &ListBillPaymentWebhooksOptions{PaginaAtual: page, ItensPorPagina: pageSize})
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Webhooks, list.Parameters.Pagination.TotalPages, nil
+	})
+}
+
 func (w *BillPaymentWebhookClient) Delete(webhookURL string) error {
 	request := BillPaymentWebhookRequest{
 		URL: webhookURL,
@@ -15,6 +15,11 @@ type BillPaymentWebhookListRequest struct {
 	EndDate   time.Time
 }
 
+type ListBillPaymentWebhooksOptions struct {
+	PaginaAtual    int
+	ItensPorPagina int
+}
+
 type BillPaymentWebhookListResponse struct {
 	Parameters BillPaymentWebhookListParameters `json:"parametros"`
 	Webhooks   []BillPaymentWebhook             `json:"webhooks"`
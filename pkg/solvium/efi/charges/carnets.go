@@ -0,0 +1,216 @@
+package charges
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type Carnets struct {
+	client *Client
+}
+
+func NewCarnets(client *Client) *Carnets {
+	return &Carnets{
+		client: client,
+	}
+}
+
+func (c *Carnets) Create(req CreateCarnetRequest) (*CarnetResponse, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := c.client.Request(http.MethodPost, "/v1/carnet", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create carnet: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to create carnet with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	var carnetResp CarnetResponse
+	if err := json.NewDecoder(resp.Body).Decode(&envelope{Data: &carnetResp}); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &carnetResp, nil
+}
+
+func (c *Carnets) Get(carnetID int) (*CarnetDetail, error) {
+	resp, err := c.client.Request(http.MethodGet, fmt.Sprintf("/v1/carnet/%d", carnetID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get carnet: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get carnet with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	var detail CarnetDetail
+	if err := json.NewDecoder(resp.Body).Decode(&envelope{Data: &detail}); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &detail, nil
+}
+
+func (c *Carnets) UpdateMetadata(carnetID int, metadata Metadata) error {
+	payload, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := c.client.Request(http.MethodPut, fmt.Sprintf("/v1/carnet/%d/metadata", carnetID), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to update carnet metadata: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update carnet metadata with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+func (c *Carnets) UpdateParcelDueDate(carnetID, parcel int, expireAt string) error {
+	req := UpdateDueDateRequest{
+		ExpireAt: expireAt,
+	}
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := c.client.Request(http.MethodPut, fmt.Sprintf("/v1/carnet/%d/parcel/%d", carnetID, parcel), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to update carnet parcel due date: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update carnet parcel due date with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+func (c *Carnets) Cancel(carnetID int) error {
+	resp, err := c.client.Request(http.MethodPut, fmt.Sprintf("/v1/carnet/%d/cancel", carnetID), nil)
+	if err != nil {
+		return fmt.Errorf("failed to cancel carnet: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to cancel carnet with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+func (c *Carnets) CancelParcel(carnetID, parcel int) error {
+	resp, err := c.client.Request(http.MethodPut, fmt.Sprintf("/v1/carnet/%d/parcel/%d/cancel", carnetID, parcel), nil)
+	if err != nil {
+		return fmt.Errorf("failed to cancel carnet parcel: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to cancel carnet parcel with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+func (c *Carnets) Settle(carnetID int) error {
+	resp, err := c.client.Request(http.MethodPut, fmt.Sprintf("/v1/carnet/%d/settle", carnetID), nil)
+	if err != nil {
+		return fmt.Errorf("failed to settle carnet: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to settle carnet with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+func (c *Carnets) SettleParcel(carnetID, parcel int) error {
+	resp, err := c.client.Request(http.MethodPut, fmt.Sprintf("/v1/carnet/%d/parcel/%d/settle", carnetID, parcel), nil)
+	if err != nil {
+		return fmt.Errorf("failed to settle carnet parcel: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to settle carnet parcel with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+func (c *Carnets) Resend(carnetID int, email string) error {
+	req := ResendRequest{
+		Email: email,
+	}
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := c.client.Request(http.MethodPost, fmt.Sprintf("/v1/carnet/%d/resend", carnetID), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to resend carnet: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to resend carnet with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+func (c *Carnets) ResendParcel(carnetID, parcel int, email string) error {
+	req := ResendRequest{
+		Email: email,
+	}
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := c.client.Request(http.MethodPost, fmt.Sprintf("/v1/carnet/%d/parcel/%d/resend", carnetID, parcel), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to resend carnet parcel: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to resend carnet parcel with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
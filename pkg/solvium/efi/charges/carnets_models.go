@@ -0,0 +1,53 @@
+package charges
+
+type CreateCarnetRequest struct {
+	Items               []Item               `json:"items"`
+	Customer            Customer             `json:"customer"`
+	ExpireAt            string               `json:"expire_at"`
+	Repeats             int                  `json:"repeats"`
+	SplitItems          bool                 `json:"split_items,omitempty"`
+	Metadata            *Metadata            `json:"metadata,omitempty"`
+	Configurations      *Configurations      `json:"configurations,omitempty"`
+	Message             string               `json:"message,omitempty"`
+	Discount            *Discount            `json:"discount,omitempty"`
+	ConditionalDiscount *ConditionalDiscount `json:"conditional_discount,omitempty"`
+}
+
+type CarnetPDF struct {
+	Carnet string `json:"carnet,omitempty"`
+	Cover  string `json:"cover,omitempty"`
+}
+
+type CarnetParcel struct {
+	ChargeID int          `json:"charge_id"`
+	Parcel   int          `json:"parcel"`
+	Status   ChargeStatus `json:"status"`
+	Value    int          `json:"value"`
+	ExpireAt string       `json:"expire_at"`
+	URL      string       `json:"url,omitempty"`
+	Barcode  string       `json:"barcode,omitempty"`
+	PDF      *ChargePDF   `json:"pdf,omitempty"`
+	Pix      *BilletPix   `json:"pix,omitempty"`
+}
+
+type CarnetResponse struct {
+	CarnetID int            `json:"carnet_id"`
+	Status   ChargeStatus   `json:"status"`
+	Cover    string         `json:"cover,omitempty"`
+	Link     string         `json:"link,omitempty"`
+	PDF      *CarnetPDF     `json:"pdf,omitempty"`
+	Charges  []CarnetParcel `json:"charges"`
+}
+
+type CarnetDetail struct {
+	CarnetID  int            `json:"carnet_id"`
+	Status    ChargeStatus   `json:"status"`
+	Repeats   int            `json:"repeats"`
+	Cover     string         `json:"cover,omitempty"`
+	Link      string         `json:"link,omitempty"`
+	Value     int            `json:"value"`
+	CustomID  string         `json:"custom_id,omitempty"`
+	CreatedAt string         `json:"created_at"`
+	Customer  *Customer      `json:"customer,omitempty"`
+	Charges   []CarnetParcel `json:"charges"`
+}
@@ -0,0 +1,234 @@
+package charges
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type Charges struct {
+	client *Client
+}
+
+func NewCharges(client *Client) *Charges {
+	return &Charges{
+		client: client,
+	}
+}
+
+func (c *Charges) Create(req CreateChargeRequest) (*ChargeResponse, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := c.client.Request(http.MethodPost, "/v1/charge", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create charge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to create charge with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	var chargeResp ChargeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&envelope{Data: &chargeResp}); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &chargeResp, nil
+}
+
+func (c *Charges) Pay(chargeID int, req PaymentRequest) (*PaymentResponse, error) {
+	body := struct {
+		Payment PaymentRequest `json:"payment"`
+	}{Payment: req}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := c.client.Request(http.MethodPost, fmt.Sprintf("/v1/charge/%d/pay", chargeID), bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to pay charge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to pay charge with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	var paymentResp PaymentResponse
+	if err := json.NewDecoder(resp.Body).Decode(&envelope{Data: &paymentResp}); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &paymentResp, nil
+}
+
+func (c *Charges) CreateOneStep(req OneStepChargeRequest) (*PaymentResponse, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := c.client.Request(http.MethodPost, "/v1/charge/one-step", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create one-step charge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to create one-step charge with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	var paymentResp PaymentResponse
+	if err := json.NewDecoder(resp.Body).Decode(&envelope{Data: &paymentResp}); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &paymentResp, nil
+}
+
+func (c *Charges) CreateBillet(req CreateChargeRequest, billet BankingBillet) (*PaymentResponse, error) {
+	return c.CreateOneStep(OneStepChargeRequest{
+		Items:     req.Items,
+		Shippings: req.Shippings,
+		Metadata:  req.Metadata,
+		Payment:   PaymentRequest{BankingBillet: &billet},
+	})
+}
+
+func (c *Charges) CreateCreditCard(req CreateChargeRequest, card CreditCard) (*PaymentResponse, error) {
+	return c.CreateOneStep(OneStepChargeRequest{
+		Items:     req.Items,
+		Shippings: req.Shippings,
+		Metadata:  req.Metadata,
+		Payment:   PaymentRequest{CreditCard: &card},
+	})
+}
+
+func (c *Charges) Get(chargeID int) (*ChargeDetail, error) {
+	resp, err := c.client.Request(http.MethodGet, fmt.Sprintf("/v1/charge/%d", chargeID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get charge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get charge with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	var detail ChargeDetail
+	if err := json.NewDecoder(resp.Body).Decode(&envelope{Data: &detail}); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &detail, nil
+}
+
+func (c *Charges) UpdateMetadata(chargeID int, metadata Metadata) error {
+	payload, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := c.client.Request(http.MethodPut, fmt.Sprintf("/v1/charge/%d/metadata", chargeID), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to update charge metadata: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update charge metadata with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+func (c *Charges) UpdateDueDate(chargeID int, expireAt string) error {
+	req := UpdateDueDateRequest{
+		ExpireAt: expireAt,
+	}
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := c.client.Request(http.MethodPut, fmt.Sprintf("/v1/charge/%d/billet", chargeID), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to update charge due date: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update charge due date with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+func (c *Charges) Cancel(chargeID int) error {
+	resp, err := c.client.Request(http.MethodPut, fmt.Sprintf("/v1/charge/%d/cancel", chargeID), nil)
+	if err != nil {
+		return fmt.Errorf("failed to cancel charge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to cancel charge with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+func (c *Charges) Settle(chargeID int) error {
+	resp, err := c.client.Request(http.MethodPut, fmt.Sprintf("/v1/charge/%d/settle", chargeID), nil)
+	if err != nil {
+		return fmt.Errorf("failed to settle charge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to settle charge with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+func (c *Charges) ResendBillet(chargeID int, email string) error {
+	req := ResendRequest{
+		Email: email,
+	}
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := c.client.Request(http.MethodPost, fmt.Sprintf("/v1/charge/%d/billet/resend", chargeID), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to resend billet: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to resend billet with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
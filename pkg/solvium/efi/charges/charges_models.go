@@ -0,0 +1,216 @@
+package charges
+
+type ChargeStatus string
+
+const (
+	ChargeStatusNew        ChargeStatus = "new"
+	ChargeStatusWaiting    ChargeStatus = "waiting"
+	ChargeStatusIdentified ChargeStatus = "identified"
+	ChargeStatusApproved   ChargeStatus = "approved"
+	ChargeStatusPaid       ChargeStatus = "paid"
+	ChargeStatusUnpaid     ChargeStatus = "unpaid"
+	ChargeStatusRefunded   ChargeStatus = "refunded"
+	ChargeStatusContested  ChargeStatus = "contested"
+	ChargeStatusCanceled   ChargeStatus = "canceled"
+	ChargeStatusSettled    ChargeStatus = "settled"
+	ChargeStatusLink       ChargeStatus = "link"
+	ChargeStatusExpired    ChargeStatus = "expired"
+	ChargeStatusActive     ChargeStatus = "active"
+	ChargeStatusFinished   ChargeStatus = "finished"
+)
+
+type PaymentMethod string
+
+const (
+	PaymentMethodBankingBillet PaymentMethod = "banking_billet"
+	PaymentMethodCreditCard    PaymentMethod = "credit_card"
+	PaymentMethodAll           PaymentMethod = "all"
+)
+
+type DiscountType string
+
+const (
+	DiscountTypeCurrency   DiscountType = "currency"
+	DiscountTypePercentage DiscountType = "percentage"
+)
+
+type Item struct {
+	Name   string `json:"name"`
+	Value  int    `json:"value"`
+	Amount int    `json:"amount,omitempty"`
+}
+
+type Shipping struct {
+	Name      string `json:"name"`
+	Value     int    `json:"value"`
+	PayeeCode string `json:"payee_code,omitempty"`
+}
+
+type Metadata struct {
+	CustomID        string `json:"custom_id,omitempty"`
+	NotificationURL string `json:"notification_url,omitempty"`
+}
+
+type Address struct {
+	Street       string `json:"street"`
+	Number       string `json:"number"`
+	Neighborhood string `json:"neighborhood"`
+	Zipcode      string `json:"zipcode"`
+	City         string `json:"city"`
+	Complement   string `json:"complement,omitempty"`
+	State        string `json:"state"`
+}
+
+type JuridicalPerson struct {
+	CorporateName string `json:"corporate_name"`
+	CNPJ          string `json:"cnpj"`
+}
+
+type Customer struct {
+	Name            string           `json:"name,omitempty"`
+	CPF             string           `json:"cpf,omitempty"`
+	Email           string           `json:"email,omitempty"`
+	PhoneNumber     string           `json:"phone_number,omitempty"`
+	Birth           string           `json:"birth,omitempty"`
+	Address         *Address         `json:"address,omitempty"`
+	JuridicalPerson *JuridicalPerson `json:"juridical_person,omitempty"`
+}
+
+type Discount struct {
+	Type  DiscountType `json:"type"`
+	Value int          `json:"value"`
+}
+
+type ConditionalDiscount struct {
+	Type      DiscountType `json:"type"`
+	Value     int          `json:"value"`
+	UntilDate string       `json:"until_date"`
+}
+
+type Configurations struct {
+	Fine     int `json:"fine,omitempty"`
+	Interest int `json:"interest,omitempty"`
+}
+
+type BankingBillet struct {
+	Customer            Customer             `json:"customer"`
+	ExpireAt            string               `json:"expire_at"`
+	Message             string               `json:"message,omitempty"`
+	Discount            *Discount            `json:"discount,omitempty"`
+	ConditionalDiscount *ConditionalDiscount `json:"conditional_discount,omitempty"`
+	Configurations      *Configurations      `json:"configurations,omitempty"`
+}
+
+type CreditCard struct {
+	Customer       Customer  `json:"customer"`
+	Installments   int       `json:"installments"`
+	PaymentToken   string    `json:"payment_token"`
+	BillingAddress *Address  `json:"billing_address,omitempty"`
+	Discount       *Discount `json:"discount,omitempty"`
+	Message        string    `json:"message,omitempty"`
+}
+
+type PaymentRequest struct {
+	BankingBillet *BankingBillet `json:"banking_billet,omitempty"`
+	CreditCard    *CreditCard    `json:"credit_card,omitempty"`
+}
+
+type CreateChargeRequest struct {
+	Items     []Item     `json:"items"`
+	Shippings []Shipping `json:"shippings,omitempty"`
+	Metadata  *Metadata  `json:"metadata,omitempty"`
+}
+
+type OneStepChargeRequest struct {
+	Items     []Item         `json:"items"`
+	Shippings []Shipping     `json:"shippings,omitempty"`
+	Metadata  *Metadata      `json:"metadata,omitempty"`
+	Payment   PaymentRequest `json:"payment"`
+}
+
+type ChargeResponse struct {
+	ChargeID  int          `json:"charge_id"`
+	Status    ChargeStatus `json:"status"`
+	Total     int          `json:"total"`
+	CustomID  string       `json:"custom_id,omitempty"`
+	CreatedAt string       `json:"created_at"`
+}
+
+type BilletPix struct {
+	QRCode      string `json:"qrcode"`
+	QRCodeImage string `json:"qrcode_image"`
+}
+
+type ChargePDF struct {
+	Charge string `json:"charge,omitempty"`
+}
+
+type PaymentResponse struct {
+	ChargeID         int           `json:"charge_id"`
+	Status           ChargeStatus  `json:"status"`
+	Total            int           `json:"total"`
+	Payment          PaymentMethod `json:"payment"`
+	CustomID         string        `json:"custom_id,omitempty"`
+	Barcode          string        `json:"barcode,omitempty"`
+	Link             string        `json:"link,omitempty"`
+	BilletLink       string        `json:"billet_link,omitempty"`
+	PDF              *ChargePDF    `json:"pdf,omitempty"`
+	ExpireAt         string        `json:"expire_at,omitempty"`
+	Pix              *BilletPix    `json:"pix,omitempty"`
+	Installments     int           `json:"installments,omitempty"`
+	InstallmentValue int           `json:"installment_value,omitempty"`
+	Reason           string        `json:"reason,omitempty"`
+}
+
+func (p *PaymentResponse) IsBolix() bool {
+	return p.Payment == PaymentMethodBankingBillet && p.Pix != nil && p.Pix.QRCode != ""
+}
+
+type ChargeHistory struct {
+	Message   string `json:"message"`
+	CreatedAt string `json:"created_at"`
+}
+
+type BankingBilletDetail struct {
+	Barcode  string     `json:"barcode"`
+	Link     string     `json:"link"`
+	PDF      *ChargePDF `json:"pdf,omitempty"`
+	ExpireAt string     `json:"expire_at"`
+	Pix      *BilletPix `json:"pix,omitempty"`
+}
+
+type CreditCardDetail struct {
+	Mask             string `json:"mask"`
+	Installments     int    `json:"installments"`
+	InstallmentValue int    `json:"installment_value"`
+}
+
+type PaymentDetail struct {
+	Method        PaymentMethod        `json:"method"`
+	CreatedAt     string               `json:"created_at"`
+	Message       string               `json:"message,omitempty"`
+	BankingBillet *BankingBilletDetail `json:"banking_billet,omitempty"`
+	CreditCard    *CreditCardDetail    `json:"credit_card,omitempty"`
+}
+
+type ChargeDetail struct {
+	ChargeID        int             `json:"charge_id"`
+	Total           int             `json:"total"`
+	Status          ChargeStatus    `json:"status"`
+	CustomID        string          `json:"custom_id,omitempty"`
+	CreatedAt       string          `json:"created_at"`
+	NotificationURL string          `json:"notification_url,omitempty"`
+	Items           []Item          `json:"items"`
+	Shippings       []Shipping      `json:"shippings,omitempty"`
+	History         []ChargeHistory `json:"history,omitempty"`
+	Customer        *Customer       `json:"customer,omitempty"`
+	Payment         *PaymentDetail  `json:"payment,omitempty"`
+}
+
+type UpdateDueDateRequest struct {
+	ExpireAt string `json:"expire_at"`
+}
+
+type ResendRequest struct {
+	Email string `json:"email"`
+}
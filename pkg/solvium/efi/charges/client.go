@@ -0,0 +1,160 @@
+package charges
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/solviumdream/solviumpayments/pkg/solvium/efi"
+)
+
+const (
+	ProductionBaseURL = "https://cobrancas.api.efipay.com.br"
+	SandboxBaseURL    = "https://cobrancas-h.api.efipay.com.br"
+)
+
+type Client struct {
+	ClientID      string
+	ClientSecret  string
+	Certificate   tls.Certificate
+	Environment   efi.Environment
+	BaseURL       string
+	Token         *efi.Token
+	HTTPClient    *http.Client
+	charges       *Charges
+	carnets       *Carnets
+	paymentLinks  *PaymentLinks
+	notifications *Notifications
+}
+
+type envelope struct {
+	Code int         `json:"code"`
+	Data interface{} `json:"data"`
+}
+
+func NewClient(efiClient *efi.Client) *Client {
+	return NewClientWithCredentials(efiClient.ClientID, efiClient.ClientSecret, efiClient.Certificate, efiClient.Environment)
+}
+
+func NewClientWithCredentials(clientID, clientSecret string, cert tls.Certificate, env efi.Environment) *Client {
+	baseURL := SandboxBaseURL
+	if env == efi.Production {
+		baseURL = ProductionBaseURL
+	}
+
+	client := &Client{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Certificate:  cert,
+		Environment:  env,
+		BaseURL:      baseURL,
+		Token:        nil,
+	}
+
+	tlsConfig := &tls.Config{}
+	if len(cert.Certificate) > 0 {
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	client.HTTPClient = &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+		Timeout: time.Second * 30,
+	}
+
+	return client
+}
+
+func (c *Client) IsTokenValid() bool {
+	if c.Token == nil {
+		return false
+	}
+	return time.Now().Before(c.Token.ExpiresAt)
+}
+
+func (c *Client) Authenticate() error {
+	if c.IsTokenValid() {
+		return nil
+	}
+
+	authHeader := base64.StdEncoding.EncodeToString([]byte(c.ClientID + ":" + c.ClientSecret))
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/v1/authorize", c.BaseURL), strings.NewReader(`{"grant_type": "client_credentials"}`))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Basic "+authHeader)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("authentication request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("authentication failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var token efi.Token
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	token.ExpiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	c.Token = &token
+
+	return nil
+}
+
+func (c *Client) Request(method, path string, body io.Reader) (*http.Response, error) {
+	if err := c.Authenticate(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, fmt.Sprintf("%s%s", c.BaseURL, path), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s %s", c.Token.TokenType, c.Token.AccessToken))
+	req.Header.Set("Content-Type", "application/json")
+
+	return c.HTTPClient.Do(req)
+}
+
+func (c *Client) Charges() *Charges {
+	if c.charges == nil {
+		c.charges = NewCharges(c)
+	}
+	return c.charges
+}
+
+func (c *Client) Carnets() *Carnets {
+	if c.carnets == nil {
+		c.carnets = NewCarnets(c)
+	}
+	return c.carnets
+}
+
+func (c *Client) PaymentLinks() *PaymentLinks {
+	if c.paymentLinks == nil {
+		c.paymentLinks = NewPaymentLinks(c)
+	}
+	return c.paymentLinks
+}
+
+func (c *Client) Notifications() *Notifications {
+	if c.notifications == nil {
+		c.notifications = NewNotifications(c)
+	}
+	return c.notifications
+}
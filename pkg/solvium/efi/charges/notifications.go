@@ -0,0 +1,145 @@
+package charges
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type NotificationType string
+
+const (
+	NotificationTypeCharge             NotificationType = "charge"
+	NotificationTypeCarnet             NotificationType = "carnet"
+	NotificationTypeCarnetCharge       NotificationType = "carnet_charge"
+	NotificationTypeSubscription       NotificationType = "subscription"
+	NotificationTypeSubscriptionCharge NotificationType = "subscription_charge"
+)
+
+type NotificationStatus struct {
+	Current  ChargeStatus `json:"current"`
+	Previous ChargeStatus `json:"previous,omitempty"`
+}
+
+type NotificationIdentifiers struct {
+	ChargeID       int `json:"charge_id,omitempty"`
+	CarnetID       int `json:"carnet_id,omitempty"`
+	SubscriptionID int `json:"subscription_id,omitempty"`
+}
+
+type NotificationEvent struct {
+	ID               int                     `json:"id"`
+	Type             NotificationType        `json:"type"`
+	CustomID         string                  `json:"custom_id,omitempty"`
+	Status           NotificationStatus      `json:"status"`
+	Identifiers      NotificationIdentifiers `json:"identifiers"`
+	Value            int                     `json:"value,omitempty"`
+	ReceivedByBankAt string                  `json:"received_by_bank_at,omitempty"`
+	CreatedAt        string                  `json:"created_at"`
+}
+
+func (e NotificationEvent) IsPaid() bool {
+	return e.Status.Current == ChargeStatusPaid || e.Status.Current == ChargeStatusSettled
+}
+
+type NotificationSubject struct {
+	Type NotificationType
+	ID   int
+}
+
+func (e NotificationEvent) Subject() NotificationSubject {
+	switch {
+	case e.Identifiers.ChargeID != 0:
+		return NotificationSubject{Type: NotificationTypeCharge, ID: e.Identifiers.ChargeID}
+	case e.Identifiers.CarnetID != 0:
+		return NotificationSubject{Type: NotificationTypeCarnet, ID: e.Identifiers.CarnetID}
+	case e.Identifiers.SubscriptionID != 0:
+		return NotificationSubject{Type: NotificationTypeSubscription, ID: e.Identifiers.SubscriptionID}
+	}
+	return NotificationSubject{Type: e.Type}
+}
+
+type Notification struct {
+	Token  string
+	Events []NotificationEvent
+}
+
+func (n *Notification) Latest() map[NotificationSubject]NotificationEvent {
+	latest := make(map[NotificationSubject]NotificationEvent)
+	for _, event := range n.Events {
+		subject := event.Subject()
+		if current, ok := latest[subject]; !ok || event.ID > current.ID {
+			latest[subject] = event
+		}
+	}
+	return latest
+}
+
+type Notifications struct {
+	client *Client
+}
+
+func NewNotifications(client *Client) *Notifications {
+	return &Notifications{
+		client: client,
+	}
+}
+
+func (n *Notifications) Get(token string) (*Notification, error) {
+	resp, err := n.client.Request(http.MethodGet, fmt.Sprintf("/v1/notification/%s", url.PathEscape(token)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get notification with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	var events []NotificationEvent
+	if err := json.NewDecoder(resp.Body).Decode(&envelope{Data: &events}); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &Notification{Token: token, Events: events}, nil
+}
+
+func (n *Notifications) GetFromRequest(r *http.Request) (*Notification, error) {
+	token, err := ParseNotificationToken(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.Get(token)
+}
+
+func ParseNotificationToken(r *http.Request) (string, error) {
+	if err := r.ParseForm(); err != nil {
+		return "", fmt.Errorf("failed to parse notification callback: %w", err)
+	}
+
+	token := strings.TrimSpace(r.FormValue("notification"))
+	if token == "" {
+		return "", fmt.Errorf("missing notification token in callback")
+	}
+
+	return token, nil
+}
+
+func ParseNotificationPayload(payload []byte) (string, error) {
+	values, err := url.ParseQuery(string(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse notification callback: %w", err)
+	}
+
+	token := strings.TrimSpace(values.Get("notification"))
+	if token == "" {
+		return "", fmt.Errorf("missing notification token in callback")
+	}
+
+	return token, nil
+}
@@ -0,0 +1,77 @@
+package charges
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/solviumdream/solviumpayments/pkg/solvium/efi"
+)
+
+func TestParseNotificationToken(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader("notification=abc-123"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	token, err := ParseNotificationToken(req)
+	if err != nil || token != "abc-123" {
+		t.Fatalf("unexpected token %q, err %v", token, err)
+	}
+
+	if token, err := ParseNotificationPayload([]byte("notification=%20def-456%20")); err != nil || token != "def-456" {
+		t.Fatalf("unexpected payload token %q, err %v", token, err)
+	}
+	if _, err := ParseNotificationPayload([]byte("other=1")); err == nil {
+		t.Fatal("expected an error for a payload without a token")
+	}
+}
+
+func TestNotificationsGetAndLatest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/notification/abc-123" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"code":200,"data":[
+			{"id":1,"type":"charge","status":{"current":"new"},"identifiers":{"charge_id":10}},
+			{"id":2,"type":"carnet","status":{"current":"up_to_date"},"identifiers":{"carnet_id":20}},
+			{"id":3,"type":"charge","status":{"current":"paid","previous":"waiting"},"identifiers":{"charge_id":10},"value":5000},
+			{"id":4,"type":"carnet","status":{"current":"canceled"},"identifiers":{"carnet_id":21}},
+			{"id":5,"type":"carnet_charge","status":{"current":"waiting"},"identifiers":{"charge_id":11,"carnet_id":20}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      &efi.Token{AccessToken: "test", TokenType: "Bearer", ExpiresAt: time.Now().Add(time.Hour)},
+	}
+
+	notification, err := client.Notifications().Get("abc-123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notification.Events) != 5 || notification.Events[2].Status.Previous != ChargeStatusWaiting {
+		t.Fatalf("unexpected events %+v", notification.Events)
+	}
+
+	latest := notification.Latest()
+	if len(latest) != 4 {
+		t.Fatalf("expected 4 subjects, got %v", latest)
+	}
+	if event := latest[NotificationSubject{Type: NotificationTypeCharge, ID: 10}]; event.ID != 3 || !event.IsPaid() {
+		t.Fatalf("unexpected latest charge event %+v", event)
+	}
+	if event := latest[NotificationSubject{Type: NotificationTypeCarnet, ID: 21}]; event.ID != 4 {
+		t.Fatalf("unexpected latest carnet event %+v", event)
+	}
+	if event := latest[NotificationSubject{Type: NotificationTypeCharge, ID: 11}]; event.ID != 5 {
+		t.Fatalf("unexpected latest carnet charge event %+v", event)
+	}
+
+	if _, err := client.Notifications().Get("missing"); err == nil {
+		t.Fatal("expected an error for an unknown token")
+	}
+}
@@ -0,0 +1,120 @@
+package charges
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type PaymentLinkSettings struct {
+	BillingAddress         bool                 `json:"billing_address,omitempty"`
+	PaymentMethod          PaymentMethod        `json:"payment_method"`
+	ExpireAt               string               `json:"expire_at"`
+	Message                string               `json:"message,omitempty"`
+	RequestDeliveryAddress bool                 `json:"request_delivery_address,omitempty"`
+	Discount               *Discount            `json:"discount,omitempty"`
+	ConditionalDiscount    *ConditionalDiscount `json:"conditional_discount,omitempty"`
+}
+
+type OneStepPaymentLinkRequest struct {
+	Items     []Item              `json:"items"`
+	Shippings []Shipping          `json:"shippings,omitempty"`
+	Metadata  *Metadata           `json:"metadata,omitempty"`
+	Settings  PaymentLinkSettings `json:"settings"`
+}
+
+type PaymentLinkResponse struct {
+	ChargeID               int           `json:"charge_id"`
+	Status                 ChargeStatus  `json:"status"`
+	Total                  int           `json:"total"`
+	CustomID               string        `json:"custom_id,omitempty"`
+	PaymentURL             string        `json:"payment_url"`
+	PaymentMethod          PaymentMethod `json:"payment_method"`
+	ConditionalDiscount    string        `json:"conditional_discount_date,omitempty"`
+	RequestDeliveryAddress bool          `json:"request_delivery_address"`
+	Message                string        `json:"message,omitempty"`
+	ExpireAt               string        `json:"expire_at"`
+	CreatedAt              string        `json:"created_at"`
+}
+
+type PaymentLinks struct {
+	client *Client
+}
+
+func NewPaymentLinks(client *Client) *PaymentLinks {
+	return &PaymentLinks{
+		client: client,
+	}
+}
+
+func (p *PaymentLinks) Define(chargeID int, settings PaymentLinkSettings) (*PaymentLinkResponse, error) {
+	payload, err := json.Marshal(settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := p.client.Request(http.MethodPost, fmt.Sprintf("/v1/charge/%d/link", chargeID), bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to define payment link: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to define payment link with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	var linkResp PaymentLinkResponse
+	if err := json.NewDecoder(resp.Body).Decode(&envelope{Data: &linkResp}); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &linkResp, nil
+}
+
+func (p *PaymentLinks) CreateOneStep(req OneStepPaymentLinkRequest) (*PaymentLinkResponse, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := p.client.Request(http.MethodPost, "/v1/charge/one-step/link", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create payment link: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to create payment link with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	var linkResp PaymentLinkResponse
+	if err := json.NewDecoder(resp.Body).Decode(&envelope{Data: &linkResp}); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &linkResp, nil
+}
+
+func (p *PaymentLinks) Update(chargeID int, settings PaymentLinkSettings) error {
+	payload, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := p.client.Request(http.MethodPut, fmt.Sprintf("/v1/charge/%d/link", chargeID), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to update payment link: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update payment link with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
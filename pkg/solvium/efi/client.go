@@ -8,7 +8,10 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/solviumdream/solviumpayments/pkg/solvium/calendar"
 )
 
 const (
@@ -39,6 +42,7 @@ type Client struct {
 	BaseURL            string
 	Token              *Token
 	HTTPClient         *http.Client
+	BusinessDays       *calendar.Scheduler
 	immediateCharges   *ImmediateCharges
 	dueCharges         *DueCharges
 	pixSend            *PixSend
@@ -49,6 +53,13 @@ type Client struct {
 	billPayment        *BillPayment
 	billPaymentWebhook *BillPaymentWebhookClient
 	openFinance        *OpenFinance
+	statements         *Statements
+	med                *MED
+	statusWatcher      *StatusWatcher
+	refunds            *Refunds
+
+	mu      sync.Mutex
+	tokenMu sync.Mutex
 }
 
 func NewClient(clientID, clientSecret string, certPath string, certPassword string, env Environment) (*Client, error) {
@@ -120,6 +131,12 @@ func NewClientFromP12(clientID, clientSecret string, p12Path string, p12Password
 }
 
 func (c *Client) IsTokenValid() bool {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	return c.tokenValid()
+}
+
+func (c *Client) tokenValid() bool {
 	if c.Token == nil {
 		return false
 	}
@@ -127,15 +144,23 @@ func (c *Client) IsTokenValid() bool {
 }
 
 func (c *Client) Authenticate() error {
-	if c.IsTokenValid() {
-		return nil
+	_, err := c.authenticate()
+	return err
+}
+
+func (c *Client) authenticate() (*Token, error) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if c.tokenValid() {
+		return c.Token, nil
 	}
 
 	authHeader := base64.StdEncoding.EncodeToString([]byte(c.ClientID + ":" + c.ClientSecret))
 
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/oauth/token", c.BaseURL), strings.NewReader(`{"grant_type": "client_credentials"}`))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Basic "+authHeader)
@@ -143,28 +168,29 @@ func (c *Client) Authenticate() error {
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("authentication request failed: %w", err)
+		return nil, fmt.Errorf("authentication request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("authentication failed with status %d: %s", resp.StatusCode, body)
+		return nil, fmt.Errorf("authentication failed with status %d: %s", resp.StatusCode, body)
 	}
 
 	var token Token
 	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
-		return fmt.Errorf("failed to decode token response: %w", err)
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
 	}
 
 	token.ExpiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
 	c.Token = &token
 
-	return nil
+	return c.Token, nil
 }
 
 func (c *Client) Request(method, path string, body io.Reader) (*http.Response, error) {
-	if err := c.Authenticate(); err != nil {
+	token, err := c.authenticate()
+	if err != nil {
 		return nil, err
 	}
 
@@ -173,13 +199,23 @@ func (c *Client) Request(method, path string, body io.Reader) (*http.Response, e
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("%s %s", c.Token.TokenType, c.Token.AccessToken))
+	req.Header.Set("Authorization", fmt.Sprintf("%s %s", token.TokenType, token.AccessToken))
 	req.Header.Set("Content-Type", "application/json")
 
 	return c.HTTPClient.Do(req)
 }
 
+func (c *Client) adjustScheduledDate(value string) (string, error) {
+	if c.BusinessDays == nil {
+		return value, nil
+	}
+	return c.BusinessDays.AdjustDate(value)
+}
+
 func (c *Client) ImmediateCharge() *ImmediateCharges {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.immediateCharges == nil {
 		c.immediateCharges = NewImmediateCharges(c)
 	}
@@ -187,6 +223,9 @@ func (c *Client) ImmediateCharge() *ImmediateCharges {
 }
 
 func (c *Client) DueCharge() *DueCharges {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.dueCharges == nil {
 		c.dueCharges = NewDueCharges(c)
 	}
@@ -194,6 +233,9 @@ func (c *Client) DueCharge() *DueCharges {
 }
 
 func (c *Client) PixSend() *PixSend {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.pixSend == nil {
 		c.pixSend = NewPixSend(c)
 	}
@@ -201,6 +243,9 @@ func (c *Client) PixSend() *PixSend {
 }
 
 func (c *Client) PixManagement() *PixManagement {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.pixManagement == nil {
 		c.pixManagement = NewPixManagement(c)
 	}
@@ -208,6 +253,9 @@ func (c *Client) PixManagement() *PixManagement {
 }
 
 func (c *Client) PayloadLocation() *PayloadLocation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.payloadLocation == nil {
 		c.payloadLocation = NewPayloadLocation(c)
 	}
@@ -215,6 +263,9 @@ func (c *Client) PayloadLocation() *PayloadLocation {
 }
 
 func (c *Client) BatchDueCharges() *BatchDueCharges {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.batchDueCharges == nil {
 		c.batchDueCharges = NewBatchDueCharges(c)
 	}
@@ -222,6 +273,9 @@ func (c *Client) BatchDueCharges() *BatchDueCharges {
 }
 
 func (c *Client) PaymentSplit() *PaymentSplit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.paymentSplit == nil {
 		c.paymentSplit = NewPaymentSplit(c)
 	}
@@ -229,6 +283,9 @@ func (c *Client) PaymentSplit() *PaymentSplit {
 }
 
 func (c *Client) BillPayment() *BillPayment {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.billPayment == nil {
 		c.billPayment = NewBillPayment(c)
 	}
@@ -236,6 +293,9 @@ func (c *Client) BillPayment() *BillPayment {
 }
 
 func (c *Client) BillPaymentWebhook() *BillPaymentWebhookClient {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.billPaymentWebhook == nil {
 		c.billPaymentWebhook = NewBillPaymentWebhook(c)
 	}
@@ -243,12 +303,55 @@ func (c *Client) BillPaymentWebhook() *BillPaymentWebhookClient {
 }
 
 func (c *Client) OpenFinance() *OpenFinance {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.openFinance == nil {
 		c.openFinance = NewOpenFinance(c)
 	}
 	return c.openFinance
 }
 
+func (c *Client) Statements() *Statements {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.statements == nil {
+		c.statements = NewStatements(c)
+	}
+	return c.statements
+}
+
+func (c *Client) MED() *MED {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.med == nil {
+		c.med = NewMED(c)
+	}
+	return c.med
+}
+
+func (c *Client) StatusWatcher() *StatusWatcher {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.statusWatcher == nil {
+		c.statusWatcher = NewStatusWatcher(c)
+	}
+	return c.statusWatcher
+}
+
+func (c *Client) Refunds() *Refunds {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.refunds == nil {
+		c.refunds = NewRefunds(c)
+	}
+	return c.refunds
+}
+
 func (c *Client) VerifyStatus(id string, txType TransactionType) (*TransactionStatus, error) {
 	status := &TransactionStatus{
 		ID:   id,
@@ -267,7 +370,7 @@ func (c *Client) VerifyStatus(id string, txType TransactionType) (*TransactionSt
 	case TransactionTypeRefund:
 		err = c.verifyRefundStatus(status)
 	default:
-		return nil, fmt.Errorf("unsupported transaction type: %s", txType)
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedTransactionType, txType)
 	}
 
 	if err != nil {
@@ -0,0 +1,67 @@
+package efi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token: &Token{
+			AccessToken: "test",
+			TokenType:   "Bearer",
+			ExpiresAt:   time.Now().Add(time.Hour),
+		},
+	}
+}
+
+func TestClientRefreshesExpiredTokenOnceUnderConcurrency(t *testing.T) {
+	var tokens int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			atomic.AddInt32(&tokens, 1)
+			w.Write([]byte(`{"access_token":"fresh","token_type":"Bearer","expires_in":3600}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	client.Token.ExpiresAt = time.Now().Add(-time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Request("GET", "/v2/cob/tx", nil)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("expected refreshed token, got status %d", resp.StatusCode)
+			}
+			client.PixSend()
+		}()
+	}
+	wg.Wait()
+
+	if tokens != 1 {
+		t.Fatalf("expected a single token refresh, got %d", tokens)
+	}
+}
@@ -2,6 +2,7 @@ package efi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -21,6 +22,12 @@ func NewDueCharges(client *Client) *DueCharges {
 }
 
 func (c *DueCharges) Create(txid string, req CreateDueChargeRequest) (*DueChargeResponse, error) {
+	dueDate, err := c.client.adjustScheduledDate(req.Calendario.DataDeVencimento)
+	if err != nil {
+		return nil, fmt.Errorf("failed to adjust due date: %w", err)
+	}
+	req.Calendario.DataDeVencimento = dueDate
+
 	bodyBytes, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -46,6 +53,12 @@ func (c *DueCharges) Create(txid string, req CreateDueChargeRequest) (*DueCharge
 }
 
 func (c *DueCharges) Review(txid string, req ReviewDueChargeRequest) (*DueChargeResponse, error) {
+	dueDate, err := c.client.adjustScheduledDate(req.Calendario.DataDeVencimento)
+	if err != nil {
+		return nil, fmt.Errorf("failed to adjust due date: %w", err)
+	}
+	req.Calendario.DataDeVencimento = dueDate
+
 	bodyBytes, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -82,6 +95,11 @@ func (c *DueCharges) Get(txid string, revision int) (*DueChargeResponse, error)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: %s", ErrChargeNotFound, body)
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		return nil, fmt.Errorf("failed to get due charge with status %d: %s", resp.StatusCode, body)
@@ -149,3 +167,22 @@ func (c *DueCharges) List(startDate, endDate time.Time, options *ListDueChargesO
 
 	return &listResp, nil
 }
+
+func (c *DueCharges) Iterate(startDate, endDate time.Time, options *ListDueChargesOptions, pageOptions *PageOptions) *Iterator[DueChargeResponse] {
+	return newPageIterator(pageOptions, func(ctx context.Context, page, pageSize int) ([]DueChargeResponse, int, error) {
+		pageFilter := ListDueChargesOptions{}
+		if options != nil {
+			pageFilter = *options
+		}
+		pageFilter.PaginaAtual = page
+		if pageSize > 0 {
+			pageFilter.ItensPorPagina = pageSize
+		}
+
+		list, err := c.List(startDate, endDate, &pageFilter)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Cobs, list.Parametros.Paginacao.QuantidadeDePaginas, nil
+	})
+}
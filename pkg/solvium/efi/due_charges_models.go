@@ -65,7 +65,9 @@ type ValorDueCharge struct {
 }
 
 type CreateDueChargeRequest struct {
+	TxID               string              `json:"txid,omitempty"`
 	Calendario         CalendarioDueCharge `json:"calendario,omitempty"`
+	Loc                *LocInfo            `json:"loc,omitempty"`
 	Devedor            DevedorDueCharge    `json:"devedor,omitempty"`
 	Valor              ValorDueCharge      `json:"valor,omitempty"`
 	Chave              string              `json:"chave,omitempty"`
@@ -74,11 +76,12 @@ type CreateDueChargeRequest struct {
 }
 
 type ReviewDueChargeRequest struct {
-	Loc                LocInfo          `json:"loc,omitempty"`
-	Devedor            DevedorDueCharge `json:"devedor,omitempty"`
-	Valor              ValorDueCharge   `json:"valor,omitempty"`
-	SolicitacaoPagador string           `json:"solicitacaoPagador,omitempty"`
-	InfoAdicionais     []InfoAdicional  `json:"infoAdicionais,omitempty"`
+	Calendario         CalendarioDueCharge `json:"calendario,omitempty"`
+	Loc                LocInfo             `json:"loc,omitempty"`
+	Devedor            DevedorDueCharge    `json:"devedor,omitempty"`
+	Valor              ValorDueCharge      `json:"valor,omitempty"`
+	SolicitacaoPagador string              `json:"solicitacaoPagador,omitempty"`
+	InfoAdicionais     []InfoAdicional     `json:"infoAdicionais,omitempty"`
 }
 
 type DueChargeResponse struct {
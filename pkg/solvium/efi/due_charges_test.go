@@ -0,0 +1,57 @@
+package efi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/solviumdream/solviumpayments/pkg/solvium/calendar"
+)
+
+func TestDueChargeWritesRollDueDates(t *testing.T) {
+	var dueDates []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Calendario CalendarioDueCharge      `json:"calendario"`
+			CobsV      []CreateDueChargeRequest `json:"cobsv"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		dueDates = append(dueDates, body.Calendario.DataDeVencimento)
+		for _, cobv := range body.CobsV {
+			dueDates = append(dueDates, cobv.Calendario.DataDeVencimento)
+		}
+
+		switch r.Method {
+		case http.MethodPut:
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+		w.Write([]byte("{}"))
+	}))
+	client.BusinessDays = calendar.NewScheduler(calendar.New(), calendar.RollFollowing)
+
+	saturday := CalendarioDueCharge{DataDeVencimento: "2030-01-12"}
+	if _, err := client.DueCharge().Review("txid", ReviewDueChargeRequest{Calendario: saturday}); err != nil {
+		t.Fatalf("unexpected review error: %v", err)
+	}
+	if _, err := client.BatchDueCharges().CreateOrUpdate("1", BatchDueChargesRequest{CobsV: []CreateDueChargeRequest{{TxID: "txid", Calendario: saturday}}}); err != nil {
+		t.Fatalf("unexpected batch error: %v", err)
+	}
+
+	expected := []string{"2030-01-14", "", "2030-01-14"}
+	if len(dueDates) != len(expected) {
+		t.Fatalf("expected %q, got %q", expected, dueDates)
+	}
+	for i := range expected {
+		if dueDates[i] != expected[i] {
+			t.Fatalf("expected %q, got %q", expected, dueDates)
+		}
+	}
+}
+
+func TestRequestPaymentRejectsNilRequest(t *testing.T) {
+	if _, err := NewBillPayment(&Client{}).RequestPayment("123", nil); err == nil {
+		t.Fatal("expected error for nil request")
+	}
+}
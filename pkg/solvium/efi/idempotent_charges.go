@@ -0,0 +1,220 @@
+package efi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+type ChargeIntentStatus string
+
+const (
+	ChargeIntentPending ChargeIntentStatus = "pending"
+	ChargeIntentCreated ChargeIntentStatus = "created"
+)
+
+var ErrChargeIntentNotFound = errors.New("charge intent not found")
+
+type ChargeIntent struct {
+	Namespace string
+	OrderID   string
+	TxID      string
+	Type      TransactionType
+	Status    ChargeIntentStatus
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ChargeIntentStore interface {
+	Get(namespace, orderID string) (*ChargeIntent, error)
+	Save(intent *ChargeIntent) error
+}
+
+type chargeIntentKey struct {
+	namespace string
+	orderID   string
+}
+
+type MemoryChargeIntentStore struct {
+	mu      sync.RWMutex
+	intents map[chargeIntentKey]ChargeIntent
+}
+
+func NewMemoryChargeIntentStore() *MemoryChargeIntentStore {
+	return &MemoryChargeIntentStore{
+		intents: make(map[chargeIntentKey]ChargeIntent),
+	}
+}
+
+func (s *MemoryChargeIntentStore) Get(namespace, orderID string) (*ChargeIntent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	intent, ok := s.intents[chargeIntentKey{namespace: namespace, orderID: orderID}]
+	if !ok {
+		return nil, ErrChargeIntentNotFound
+	}
+	return &intent, nil
+}
+
+func (s *MemoryChargeIntentStore) Save(intent *ChargeIntent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.intents[chargeIntentKey{namespace: intent.Namespace, orderID: intent.OrderID}] = *intent
+	return nil
+}
+
+type ImmediateChargeResult struct {
+	Charge  *ImmediateChargeResponse
+	Intent  *ChargeIntent
+	Created bool
+}
+
+type DueChargeResult struct {
+	Charge  *DueChargeResponse
+	Intent  *ChargeIntent
+	Created bool
+}
+
+type IdempotentCharges struct {
+	client *Client
+	store  ChargeIntentStore
+
+	Namespace string
+}
+
+func NewIdempotentCharges(client *Client, store ChargeIntentStore, namespace string) *IdempotentCharges {
+	return &IdempotentCharges{
+		client:    client,
+		store:     store,
+		Namespace: namespace,
+	}
+}
+
+func TxIDForOrder(namespace, orderID string) string {
+	sum := sha256.Sum256([]byte(namespace + ":" + orderID))
+	return hex.EncodeToString(sum[:])[:32]
+}
+
+func (s *IdempotentCharges) CreateImmediate(ctx context.Context, orderID string, req CreateImmediateChargeRequest) (*ImmediateChargeResult, error) {
+	intent, existed, err := s.intent(orderID, TransactionTypeCharge)
+	if err != nil {
+		return nil, err
+	}
+
+	if existed {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		charge, err := s.client.ImmediateCharge().GetCharge(intent.TxID, 0)
+		switch {
+		case err == nil:
+			return &ImmediateChargeResult{Charge: charge, Intent: intent, Created: false}, s.markCreated(intent)
+		case !errors.Is(err, ErrChargeNotFound):
+			return nil, err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	created, createErr := s.client.ImmediateCharge().CreateWithTxid(intent.TxID, req)
+	if createErr != nil {
+		charge, err := s.client.ImmediateCharge().GetCharge(intent.TxID, 0)
+		if err != nil {
+			return nil, createErr
+		}
+		return &ImmediateChargeResult{Charge: charge, Intent: intent, Created: false}, s.markCreated(intent)
+	}
+
+	return &ImmediateChargeResult{Charge: created, Intent: intent, Created: true}, s.markCreated(intent)
+}
+
+func (s *IdempotentCharges) CreateDue(ctx context.Context, orderID string, req CreateDueChargeRequest) (*DueChargeResult, error) {
+	intent, existed, err := s.intent(orderID, TransactionTypeDueCharge)
+	if err != nil {
+		return nil, err
+	}
+
+	if existed {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		charge, err := s.client.DueCharge().Get(intent.TxID, 0)
+		switch {
+		case err == nil:
+			return &DueChargeResult{Charge: charge, Intent: intent, Created: false}, s.markCreated(intent)
+		case !errors.Is(err, ErrChargeNotFound):
+			return nil, err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	created, createErr := s.client.DueCharge().Create(intent.TxID, req)
+	if createErr != nil {
+		charge, err := s.client.DueCharge().Get(intent.TxID, 0)
+		if err != nil {
+			return nil, createErr
+		}
+		return &DueChargeResult{Charge: charge, Intent: intent, Created: false}, s.markCreated(intent)
+	}
+
+	return &DueChargeResult{Charge: created, Intent: intent, Created: true}, s.markCreated(intent)
+}
+
+func (s *IdempotentCharges) intent(orderID string, txType TransactionType) (*ChargeIntent, bool, error) {
+	if strings.TrimSpace(orderID) == "" {
+		return nil, false, fmt.Errorf("order ID is required")
+	}
+
+	intent, err := s.store.Get(s.Namespace, orderID)
+	switch {
+	case err == nil:
+		if intent.Type != txType {
+			return nil, false, fmt.Errorf("order %s already has a %s charge intent", orderID, intent.Type)
+		}
+		return intent, true, nil
+	case !errors.Is(err, ErrChargeIntentNotFound):
+		return nil, false, fmt.Errorf("failed to load charge intent: %w", err)
+	}
+
+	now := time.Now()
+	intent = &ChargeIntent{
+		Namespace: s.Namespace,
+		OrderID:   orderID,
+		TxID:      TxIDForOrder(s.Namespace, orderID),
+		Type:      txType,
+		Status:    ChargeIntentPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.store.Save(intent); err != nil {
+		return nil, false, fmt.Errorf("failed to record charge intent: %w", err)
+	}
+
+	return intent, false, nil
+}
+
+func (s *IdempotentCharges) markCreated(intent *ChargeIntent) error {
+	if intent.Status == ChargeIntentCreated {
+		return nil
+	}
+
+	intent.Status = ChargeIntentCreated
+	intent.UpdatedAt = time.Now()
+	if err := s.store.Save(intent); err != nil {
+		return fmt.Errorf("failed to update charge intent: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package efi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestIdempotentChargesCreateImmediate(t *testing.T) {
+	txid := TxIDForOrder("shop", "order-1")
+
+	tests := []struct {
+		name    string
+		intent  *ChargeIntent
+		get     int
+		put     int
+		calls   []string
+		created bool
+		err     bool
+	}{
+		{name: "intent and charge exist", intent: &ChargeIntent{Namespace: "shop", OrderID: "order-1", TxID: txid, Type: TransactionTypeCharge, Status: ChargeIntentPending}, get: http.StatusOK, calls: []string{"GET"}},
+		{name: "intent exists without charge", intent: &ChargeIntent{Namespace: "shop", OrderID: "order-1", TxID: txid, Type: TransactionTypeCharge, Status: ChargeIntentPending}, get: http.StatusNotFound, put: http.StatusCreated, calls: []string{"GET", "PUT"}, created: true},
+		{name: "create conflict then fetch", get: http.StatusOK, put: http.StatusConflict, calls: []string{"PUT", "GET"}},
+		{name: "create fails and charge is missing", get: http.StatusNotFound, put: http.StatusConflict, calls: []string{"PUT", "GET"}, err: true},
+		{name: "type mismatch", intent: &ChargeIntent{Namespace: "shop", OrderID: "order-1", TxID: txid, Type: TransactionTypeDueCharge, Status: ChargeIntentPending}, err: true},
+		{name: "intent in another namespace", intent: &ChargeIntent{Namespace: "subscriptions", OrderID: "order-1", TxID: "other", Type: TransactionTypeDueCharge, Status: ChargeIntentCreated}, put: http.StatusCreated, calls: []string{"PUT"}, created: true},
+		{name: "lookup error", intent: &ChargeIntent{Namespace: "shop", OrderID: "order-1", TxID: txid, Type: TransactionTypeCharge, Status: ChargeIntentPending}, get: http.StatusInternalServerError, calls: []string{"GET"}, err: true},
+	}
+
+	for _, tt := range tests {
+		var calls []string
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/v2/cob/"+txid {
+				t.Errorf("%s: unexpected path %s", tt.name, r.URL.Path)
+			}
+			calls = append(calls, r.Method)
+
+			status := tt.get
+			if r.Method == http.MethodPut {
+				status = tt.put
+			}
+			w.WriteHeader(status)
+			if status == http.StatusOK || status == http.StatusCreated {
+				json.NewEncoder(w).Encode(ImmediateChargeResponse{TxID: txid, Status: StatusChargeActive})
+			}
+		}))
+
+		store := NewMemoryChargeIntentStore()
+		if tt.intent != nil {
+			store.Save(tt.intent)
+		}
+
+		result, err := NewIdempotentCharges(client, store, "shop").CreateImmediate(context.Background(), "order-1", CreateImmediateChargeRequest{})
+		if len(calls) != len(tt.calls) {
+			t.Errorf("%s: expected calls %v, got %v", tt.name, tt.calls, calls)
+		} else {
+			for i := range calls {
+				if calls[i] != tt.calls[i] {
+					t.Errorf("%s: expected calls %v, got %v", tt.name, tt.calls, calls)
+					break
+				}
+			}
+		}
+
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s: expected error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if result.Created != tt.created || result.Charge.TxID != txid {
+			t.Errorf("%s: unexpected result %+v", tt.name, result)
+		}
+
+		intent, _ := store.Get("shop", "order-1")
+		if intent.Status != ChargeIntentCreated {
+			t.Errorf("%s: expected intent to be marked created, got %s", tt.name, intent.Status)
+		}
+	}
+}
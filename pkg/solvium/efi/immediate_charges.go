@@ -2,7 +2,9 @@ package efi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,6 +13,9 @@ import (
 )
 
 
+var ErrChargeNotFound = errors.New("charge not found")
+
+
 type ImmediateCharges struct {
 	client *Client
 }
@@ -24,6 +29,10 @@ func NewImmediateCharges(client *Client) *ImmediateCharges {
 
 
 func (c *ImmediateCharges) CreateWithoutTxid(req CreateImmediateChargeRequest) (*ImmediateChargeResponse, error) {
+	if err := req.Valor.Validate(); err != nil {
+		return nil, err
+	}
+
 	bodyBytes, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -50,6 +59,10 @@ func (c *ImmediateCharges) CreateWithoutTxid(req CreateImmediateChargeRequest) (
 
 
 func (c *ImmediateCharges) CreateWithTxid(txid string, req CreateImmediateChargeRequest) (*ImmediateChargeResponse, error) {
+	if err := req.Valor.Validate(); err != nil {
+		return nil, err
+	}
+
 	bodyBytes, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -76,6 +89,10 @@ func (c *ImmediateCharges) CreateWithTxid(txid string, req CreateImmediateCharge
 
 
 func (c *ImmediateCharges) ReviewCharge(txid string, req ReviewChargeRequest) (*ImmediateChargeResponse, error) {
+	if err := req.Valor.Validate(); err != nil {
+		return nil, err
+	}
+
 	bodyBytes, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -113,6 +130,11 @@ func (c *ImmediateCharges) GetCharge(txid string, revision int) (*ImmediateCharg
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: %s", ErrChargeNotFound, body)
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		return nil, fmt.Errorf("failed to get charge with status %d: %s", resp.StatusCode, body)
@@ -182,3 +204,22 @@ func (c *ImmediateCharges) ListCharges(startDate, endDate time.Time, options *Li
 
 	return &listResp, nil
 }
+
+func (c *ImmediateCharges) IterateCharges(startDate, endDate time.Time, options *ListChargesOptions, pageOptions *PageOptions) *Iterator[ImmediateChargeResponse] {
+	return newPageIterator(pageOptions, func(ctx context.Context, page, pageSize int) ([]ImmediateChargeResponse, int, error) {
+		pageFilter := ListChargesOptions{}
+		if options != nil {
+			pageFilter = *options
+		}
+		pageFilter.PaginaAtual = page
+		if pageSize > 0 {
+			pageFilter.ItensPorPagina = pageSize
+		}
+
+		list, err := c.ListCharges(startDate, endDate, &pageFilter)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Cobs, list.Parametros.Paginacao.QuantidadeDePaginas, nil
+	})
+}
@@ -0,0 +1,188 @@
+package efi
+
+import "context"
+
+type PageOptions struct {
+	PageSize int
+	Prefetch int
+}
+
+type Iterator[T any] struct {
+	fetch   func(ctx context.Context) ([]T, bool, error)
+	stop    func()
+	buffer  []T
+	current T
+	more    bool
+	err     error
+}
+
+func newIterator[T any](fetch func(ctx context.Context) ([]T, bool, error)) *Iterator[T] {
+	return &Iterator[T]{
+		fetch: fetch,
+		more:  true,
+	}
+}
+
+func newPageIterator[T any](options *PageOptions, fetchPage func(ctx context.Context, page, pageSize int) ([]T, int, error)) *Iterator[T] {
+	var pageSize, prefetch int
+	if options != nil {
+		pageSize = options.PageSize
+		prefetch = options.Prefetch
+	}
+
+	if prefetch <= 0 {
+		page := 0
+		return newIterator(func(ctx context.Context) ([]T, bool, error) {
+			items, pages, err := fetchPage(ctx, page, pageSize)
+			page++
+			return items, page < pages, err
+		})
+	}
+
+	prefetcher := &pagePrefetcher[T]{fetchPage: fetchPage, pageSize: pageSize, workers: prefetch}
+	it := newIterator(prefetcher.next)
+	it.stop = prefetcher.close
+	return it
+}
+
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	for len(it.buffer) == 0 {
+		if it.err != nil || !it.more {
+			it.Close()
+			return false
+		}
+		if err := ctx.Err(); err != nil {
+			it.err = err
+			it.Close()
+			return false
+		}
+
+		items, more, err := it.fetch(ctx)
+		if err != nil {
+			it.err = err
+			it.Close()
+			return false
+		}
+		it.buffer = items
+		it.more = more && len(items) > 0
+	}
+
+	it.current = it.buffer[0]
+	it.buffer = it.buffer[1:]
+	return true
+}
+
+func (it *Iterator[T]) Item() T {
+	return it.current
+}
+
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+func (it *Iterator[T]) Close() {
+	if it.stop != nil {
+		it.stop()
+	}
+}
+
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var items []T
+	for it.Next(ctx) {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+func (it *Iterator[T]) ForEach(ctx context.Context, fn func(item T) error) error {
+	defer it.Close()
+
+	for it.Next(ctx) {
+		if err := fn(it.Item()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+type pageResult[T any] struct {
+	items []T
+	err   error
+}
+
+type pagePrefetcher[T any] struct {
+	fetchPage func(ctx context.Context, page, pageSize int) ([]T, int, error)
+	pageSize  int
+	workers   int
+
+	page    int
+	pages   int
+	results []chan pageResult[T]
+	slots   chan struct{}
+	cancel  context.CancelFunc
+}
+
+func (p *pagePrefetcher[T]) next(ctx context.Context) ([]T, bool, error) {
+	if p.results == nil {
+		items, pages, err := p.fetchPage(ctx, 0, p.pageSize)
+		if err != nil {
+			return nil, false, err
+		}
+
+		p.page = 1
+		p.pages = pages
+		p.results = make([]chan pageResult[T], pages)
+		if pages > 1 {
+			p.start(ctx)
+		}
+		return items, p.page < p.pages, nil
+	}
+
+	var result pageResult[T]
+	select {
+	case result = <-p.results[p.page]:
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
+	}
+	select {
+	case <-p.slots:
+	default:
+	}
+
+	p.page++
+	return result.items, p.page < p.pages, result.err
+}
+
+func (p *pagePrefetcher[T]) start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	p.cancel = cancel
+	p.slots = make(chan struct{}, p.workers)
+
+	for page := 1; page < p.pages; page++ {
+		p.results[page] = make(chan pageResult[T], 1)
+	}
+
+	go func() {
+		for page := 1; page < p.pages; page++ {
+			select {
+			case p.slots <- struct{}{}:
+			case <-ctx.Done():
+				for ; page < p.pages; page++ {
+					p.results[page] <- pageResult[T]{err: ctx.Err()}
+				}
+				return
+			}
+
+			go func(page int) {
+				items, _, err := p.fetchPage(ctx, page, p.pageSize)
+				p.results[page] <- pageResult[T]{items: items, err: err}
+			}(page)
+		}
+	}()
+}
+
+func (p *pagePrefetcher[T]) close() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
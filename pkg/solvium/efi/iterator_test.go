@@ -0,0 +1,57 @@
+package efi
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPageIteratorWalksAllPages(t *testing.T) {
+	for _, prefetch := range []int{0, 1, 3} {
+		var calls int32
+		it := newPageIterator(&PageOptions{PageSize: 2, Prefetch: prefetch}, func(ctx context.Context, page, pageSize int) ([]int, int, error) {
+			atomic.AddInt32(&calls, 1)
+			if pageSize != 2 {
+				t.Errorf("unexpected page size %d", pageSize)
+			}
+			time.Sleep(time.Duration(5-page) * time.Millisecond)
+			return []int{page * 2, page*2 + 1}, 5, nil
+		})
+
+		items, err := it.All(context.Background())
+		if err != nil {
+			t.Fatalf("prefetch %d: %v", prefetch, err)
+		}
+		if len(items) != 10 {
+			t.Fatalf("prefetch %d: got %d items", prefetch, len(items))
+		}
+		for i, item := range items {
+			if item != i {
+				t.Fatalf("prefetch %d: item %d is %d", prefetch, i, item)
+			}
+		}
+		if calls != 5 {
+			t.Fatalf("prefetch %d: fetched %d pages", prefetch, calls)
+		}
+	}
+}
+
+func TestPageIteratorStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	it := newPageIterator(&PageOptions{Prefetch: 2}, func(ctx context.Context, page, pageSize int) ([]int, int, error) {
+		return []int{page}, 100, nil
+	})
+
+	err := it.ForEach(ctx, func(item int) error {
+		if item == 3 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package efi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type MED struct {
+	client *Client
+}
+
+func NewMED(client *Client) *MED {
+	return &MED{
+		client: client,
+	}
+}
+
+type ListMEDOptions struct {
+	Status         MEDInfractionStatus
+	EndToEndID     string
+	PaginaAtual    int
+	ItensPorPagina int
+}
+
+func (m *MED) ListInfractions(startDate, endDate time.Time, options *ListMEDOptions) (*MEDInfractionListResponse, error) {
+	query := medListQuery(startDate, endDate, options)
+
+	path := fmt.Sprintf("/v2/gn/infracoes?%s", query.Encode())
+	resp, err := m.client.Request(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list infractions: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to list infractions with status %d: %s", resp.StatusCode, body)
+	}
+
+	var listResp MEDInfractionListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&listResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &listResp, nil
+}
+
+func (m *MED) GetInfraction(id string) (*MEDInfraction, error) {
+	resp, err := m.client.Request(http.MethodGet, fmt.Sprintf("/v2/gn/infracoes/%s", url.PathEscape(id)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get infraction: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get infraction with status %d: %s", resp.StatusCode, body)
+	}
+
+	var infraction MEDInfraction
+	if err := json.NewDecoder(resp.Body).Decode(&infraction); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &infraction, nil
+}
+
+func (m *MED) SubmitDefense(id string, req MEDDefenseRequest) (*MEDInfraction, error) {
+	if req.Analise != MEDDefenseAccepted && req.Analise != MEDDefenseRejected {
+		return nil, fmt.Errorf("invalid defense analysis %q", req.Analise)
+	}
+	if req.Justificativa == "" {
+		return nil, fmt.Errorf("defense justification is required")
+	}
+
+	bodyBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := m.client.Request(http.MethodPost, fmt.Sprintf("/v2/gn/infracoes/%s/defesa", url.PathEscape(id)), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit defense: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to submit defense with status %d: %s", resp.StatusCode, body)
+	}
+
+	var infraction MEDInfraction
+	if err := json.NewDecoder(resp.Body).Decode(&infraction); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &infraction, nil
+}
+
+func (m *MED) ListSpecialRefunds(startDate, endDate time.Time, options *ListMEDOptions) (*MEDSpecialRefundListResponse, error) {
+	query := medListQuery(startDate, endDate, options)
+
+	path := fmt.Sprintf("/v2/gn/devolucoes-especiais?%s", query.Encode())
+	resp, err := m.client.Request(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list special refunds: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to list special refunds with status %d: %s", resp.StatusCode, body)
+	}
+
+	var listResp MEDSpecialRefundListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&listResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &listResp, nil
+}
+
+func (m *MED) GetSpecialRefund(id string) (*MEDSpecialRefund, error) {
+	resp, err := m.client.Request(http.MethodGet, fmt.Sprintf("/v2/gn/devolucoes-especiais/%s", url.PathEscape(id)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get special refund: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get special refund with status %d: %s", resp.StatusCode, body)
+	}
+
+	var refund MEDSpecialRefund
+	if err := json.NewDecoder(resp.Body).Decode(&refund); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &refund, nil
+}
+
+func (m *MED) GetPix(infraction *MEDInfraction) (*PixDetail, error) {
+	if infraction.EndToEndID == "" {
+		return nil, fmt.Errorf("infraction %s has no endToEndId", infraction.ID)
+	}
+
+	return m.client.PixManagement().GetByE2EID(infraction.EndToEndID)
+}
+
+func (m *MED) GetInfractionDetail(id string, startDate, endDate time.Time) (*MEDInfractionDetail, error) {
+	infraction, err := m.GetInfraction(id)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &MEDInfractionDetail{Infraction: *infraction}
+
+	detail.Pix, err = m.GetPix(infraction)
+	if err != nil {
+		return nil, err
+	}
+
+	refunds, err := m.ListSpecialRefunds(startDate, endDate, &ListMEDOptions{EndToEndID: infraction.EndToEndID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, refund := range refunds.DevolucoesEspeciais {
+		if refund.IDInfracao == infraction.ID || (refund.IDInfracao == "" && refund.EndToEndID == infraction.EndToEndID) {
+			detail.SpecialRefunds = append(detail.SpecialRefunds, refund)
+		}
+	}
+
+	return detail, nil
+}
+
+func medListQuery(startDate, endDate time.Time, options *ListMEDOptions) url.Values {
+	query := url.Values{}
+	query.Add("inicio", startDate.Format(time.RFC3339))
+	query.Add("fim", endDate.Format(time.RFC3339))
+
+	if options != nil {
+		if options.Status != "" {
+			query.Add("status", string(options.Status))
+		}
+		if options.EndToEndID != "" {
+			query.Add("endToEndId", options.EndToEndID)
+		}
+		if options.PaginaAtual > 0 {
+			query.Add("paginacao.paginaAtual", fmt.Sprintf("%d", options.PaginaAtual))
+		}
+		if options.ItensPorPagina > 0 {
+			query.Add("paginacao.itensPorPagina", fmt.Sprintf("%d", options.ItensPorPagina))
+		}
+	}
+
+	return query
+}
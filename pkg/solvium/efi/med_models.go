@@ -0,0 +1,82 @@
+package efi
+
+type MEDInfractionStatus string
+
+const (
+	MEDInfractionStatusOpen     MEDInfractionStatus = "ABERTA"
+	MEDInfractionStatusAnalysis MEDInfractionStatus = "EM_ANALISE"
+	MEDInfractionStatusAccepted MEDInfractionStatus = "ACEITA"
+	MEDInfractionStatusRejected MEDInfractionStatus = "REJEITADA"
+	MEDInfractionStatusCanceled MEDInfractionStatus = "CANCELADA"
+)
+
+type MEDDefenseAnalysis string
+
+const (
+	MEDDefenseAccepted MEDDefenseAnalysis = "aceito"
+	MEDDefenseRejected MEDDefenseAnalysis = "rejeitado"
+)
+
+type MEDSpecialRefundStatus string
+
+const (
+	MEDSpecialRefundStatusProcessing MEDSpecialRefundStatus = "EM_PROCESSAMENTO"
+	MEDSpecialRefundStatusCompleted  MEDSpecialRefundStatus = "DEVOLVIDO"
+	MEDSpecialRefundStatusFailed     MEDSpecialRefundStatus = "NAO_REALIZADO"
+)
+
+type MEDInfraction struct {
+	ID            string              `json:"idInfracao"`
+	EndToEndID    string              `json:"endToEndId"`
+	Tipo          string              `json:"tipo,omitempty"`
+	Motivo        string              `json:"motivo,omitempty"`
+	Descricao     string              `json:"descricao,omitempty"`
+	Status        MEDInfractionStatus `json:"status"`
+	Valor         string              `json:"valor,omitempty"`
+	Chave         string              `json:"chave,omitempty"`
+	Criacao       string              `json:"criacao,omitempty"`
+	PrazoDefesa   string              `json:"prazoDefesa,omitempty"`
+	Analise       MEDDefenseAnalysis  `json:"analise,omitempty"`
+	Justificativa string              `json:"justificativa,omitempty"`
+}
+
+type MEDInfractionListResponse struct {
+	Parametros Parametros      `json:"parametros,omitempty"`
+	Infracoes  []MEDInfraction `json:"infracoes,omitempty"`
+}
+
+type MEDAttachment struct {
+	Nome    string `json:"nome"`
+	Tipo    string `json:"tipo,omitempty"`
+	Tamanho int64  `json:"tamanho,omitempty"`
+	Hash    string `json:"hash,omitempty"`
+	URL     string `json:"url,omitempty"`
+}
+
+type MEDDefenseRequest struct {
+	Analise       MEDDefenseAnalysis `json:"analise"`
+	Justificativa string             `json:"justificativa"`
+	Anexos        []MEDAttachment    `json:"anexos,omitempty"`
+}
+
+type MEDSpecialRefund struct {
+	ID         string                 `json:"id"`
+	IDInfracao string                 `json:"idInfracao,omitempty"`
+	EndToEndID string                 `json:"endToEndId"`
+	RtrID      string                 `json:"rtrId,omitempty"`
+	Valor      string                 `json:"valor"`
+	Status     MEDSpecialRefundStatus `json:"status"`
+	Motivo     string                 `json:"motivo,omitempty"`
+	Horario    HorarioRefund          `json:"horario,omitempty"`
+}
+
+type MEDSpecialRefundListResponse struct {
+	Parametros          Parametros         `json:"parametros,omitempty"`
+	DevolucoesEspeciais []MEDSpecialRefund `json:"devolucoesEspeciais,omitempty"`
+}
+
+type MEDInfractionDetail struct {
+	Infraction     MEDInfraction
+	Pix            *PixDetail
+	SpecialRefunds []MEDSpecialRefund
+}
@@ -3,6 +3,7 @@ package efi
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -236,7 +237,18 @@ func (o *OpenFinance) RefundPayment(paymentID string, value string) (*OpenFinanc
 
 
 func (o *OpenFinance) InitiateScheduledPayment(request *OpenFinanceScheduledPaymentRequest) (*OpenFinancePaymentResponse, error) {
-	payload, err := json.Marshal(request)
+	if request == nil {
+		return nil, errors.New("scheduled payment request is required")
+	}
+
+	scheduledDate, err := o.client.adjustScheduledDate(request.Payment.ScheduledDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to adjust scheduled date: %w", err)
+	}
+	adjusted := *request
+	adjusted.Payment.ScheduledDate = scheduledDate
+
+	payload, err := json.Marshal(adjusted)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal scheduled payment request: %w", err)
 	}
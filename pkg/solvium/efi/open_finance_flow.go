@@ -0,0 +1,325 @@
+package efi
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultOpenFinanceFlowCookie = "efi_of_state"
+	DefaultOpenFinanceFlowTTL    = 30 * time.Minute
+)
+
+var (
+	ErrFlowStateNotFound = errors.New("open finance flow state not found")
+	ErrFlowStateExpired  = errors.New("open finance flow state expired")
+	ErrFlowStateMismatch = errors.New("open finance flow state does not match the returning session")
+	ErrFlowStateConsumed = errors.New("open finance flow state was already used")
+)
+
+var openFinanceRedirectErrors = map[string]OpenFinanceRedirectError{
+	"acesso_negado":          {Message: "payer denied access at the account holder institution", Retry: true},
+	"consentimento_expirado": {Message: "payment consent expired before authorization", Retry: true},
+	"pagamento_rejeitado":    {Message: "payment was rejected by the account holder institution", Retry: false},
+	"erro_interno":           {Message: "internal error at the account holder institution", Retry: true},
+}
+
+func MapOpenFinanceRedirectError(code string) *OpenFinanceRedirectError {
+	if code == "" {
+		return nil
+	}
+
+	if known, ok := openFinanceRedirectErrors[code]; ok {
+		known.Code = code
+		return &known
+	}
+
+	return &OpenFinanceRedirectError{Code: code, Message: "unknown error returned by the account holder institution"}
+}
+
+type OpenFinanceFlowStore interface {
+	Save(state *OpenFinanceFlowState) error
+	Get(paymentID string) (*OpenFinanceFlowState, error)
+	Consume(paymentID string) (*OpenFinanceFlowState, error)
+	Delete(paymentID string) error
+}
+
+type MemoryOpenFinanceFlowStore struct {
+	mu     sync.RWMutex
+	states map[string]OpenFinanceFlowState
+}
+
+func NewMemoryOpenFinanceFlowStore() *MemoryOpenFinanceFlowStore {
+	return &MemoryOpenFinanceFlowStore{
+		states: make(map[string]OpenFinanceFlowState),
+	}
+}
+
+func (s *MemoryOpenFinanceFlowStore) Save(state *OpenFinanceFlowState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[state.PaymentID] = *state
+	return nil
+}
+
+func (s *MemoryOpenFinanceFlowStore) Get(paymentID string) (*OpenFinanceFlowState, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state, ok := s.states[paymentID]
+	if !ok {
+		return nil, ErrFlowStateNotFound
+	}
+	return &state, nil
+}
+
+func (s *MemoryOpenFinanceFlowStore) Consume(paymentID string) (*OpenFinanceFlowState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.states[paymentID]
+	if !ok {
+		return nil, ErrFlowStateNotFound
+	}
+	if !state.ReturnedAt.IsZero() {
+		return nil, ErrFlowStateConsumed
+	}
+
+	state.ReturnedAt = time.Now()
+	s.states[paymentID] = state
+	return &state, nil
+}
+
+func (s *MemoryOpenFinanceFlowStore) Delete(paymentID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.states, paymentID)
+	return nil
+}
+
+type OpenFinanceFlow struct {
+	openFinance *OpenFinance
+	store       OpenFinanceFlowStore
+
+	TTL          time.Duration
+	CookieName   string
+	CookiePath   string
+	SecureCookie bool
+	SessionID    func(r *http.Request) (string, error)
+	OnComplete   func(w http.ResponseWriter, r *http.Request, result *OpenFinanceFlowResult, err error)
+}
+
+func NewOpenFinanceFlow(openFinance *OpenFinance, store OpenFinanceFlowStore) *OpenFinanceFlow {
+	return &OpenFinanceFlow{
+		openFinance:  openFinance,
+		store:        store,
+		TTL:          DefaultOpenFinanceFlowTTL,
+		CookieName:   DefaultOpenFinanceFlowCookie,
+		CookiePath:   "/",
+		SecureCookie: true,
+	}
+}
+
+func (o *OpenFinance) Flow(store OpenFinanceFlowStore) *OpenFinanceFlow {
+	return NewOpenFinanceFlow(o, store)
+}
+
+func (f *OpenFinanceFlow) Start(sessionID string, request *OpenFinancePaymentRequest) (*OpenFinanceFlowState, error) {
+	token, err := newFlowToken()
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := f.openFinance.InitiatePayment(request)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	state := &OpenFinanceFlowState{
+		PaymentID:   payment.PaymentID,
+		SessionID:   sessionID,
+		Token:       token,
+		OwnID:       request.Payment.OwnID,
+		RedirectURI: payment.RedirectURI,
+		Status:      FlowStatusStarted,
+		CreatedAt:   now,
+	}
+	if f.TTL > 0 {
+		state.ExpiresAt = now.Add(f.TTL)
+	}
+
+	if err := f.store.Save(state); err != nil {
+		return nil, fmt.Errorf("failed to save flow state: %w", err)
+	}
+
+	return state, nil
+}
+
+func (f *OpenFinanceFlow) Begin(w http.ResponseWriter, r *http.Request, request *OpenFinancePaymentRequest) (*OpenFinanceFlowState, error) {
+	sessionID, err := f.sessionID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := f.Start(sessionID, request)
+	if err != nil {
+		return nil, err
+	}
+
+	cookie := &http.Cookie{
+		Name:     f.CookieName,
+		Value:    state.Token,
+		Path:     f.CookiePath,
+		HttpOnly: true,
+		Secure:   f.SecureCookie,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if !state.ExpiresAt.IsZero() {
+		cookie.Expires = state.ExpiresAt
+	}
+	http.SetCookie(w, cookie)
+
+	return state, nil
+}
+
+func (f *OpenFinanceFlow) Complete(sessionID, token string, params *OpenFinanceRedirectParams) (*OpenFinanceFlowResult, error) {
+	state, err := f.store.Get(params.PaymentIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Expired(time.Now()) {
+		return nil, ErrFlowStateExpired
+	}
+
+	if subtle.ConstantTimeCompare([]byte(state.Token), []byte(token)) != 1 ||
+		subtle.ConstantTimeCompare([]byte(state.SessionID), []byte(sessionID)) != 1 {
+		return nil, ErrFlowStateMismatch
+	}
+
+	if !state.ReturnedAt.IsZero() {
+		return nil, ErrFlowStateConsumed
+	}
+
+	redirectErr := MapOpenFinanceRedirectError(params.Error)
+
+	var payment *OpenFinancePayment
+	if redirectErr == nil {
+		payment, err = f.openFinance.GetPayment(state.PaymentID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	consumed, err := f.store.Consume(state.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	state.ReturnedAt = consumed.ReturnedAt
+
+	result := &OpenFinanceFlowResult{State: state}
+
+	if redirectErr != nil {
+		state.Status = FlowStatusFailed
+		state.ErrorCode = redirectErr.Code
+		result.Error = redirectErr
+	} else {
+		result.Payment = payment
+		state.PaymentStatus = payment.Status
+		switch payment.Status {
+		case PaymentStatusAccepted, PaymentStatusCompleted:
+			state.Status = FlowStatusCompleted
+		case PaymentStatusRejected:
+			state.Status = FlowStatusFailed
+		default:
+			state.Status = FlowStatusPending
+		}
+	}
+
+	if err := f.store.Save(state); err != nil {
+		return nil, fmt.Errorf("failed to save flow state: %w", err)
+	}
+
+	return result, nil
+}
+
+func (f *OpenFinanceFlow) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	result, err := f.handleRedirect(r)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     f.CookieName,
+		Value:    "",
+		Path:     f.CookiePath,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   f.SecureCookie,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	if f.OnComplete != nil {
+		f.OnComplete(w, r, result, err)
+		return
+	}
+
+	if err != nil {
+		status := http.StatusBadGateway
+		switch {
+		case errors.Is(err, ErrFlowStateMismatch):
+			status = http.StatusForbidden
+		case errors.Is(err, ErrFlowStateNotFound), errors.Is(err, ErrFlowStateExpired), errors.Is(err, ErrFlowStateConsumed):
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result.public())
+}
+
+func (f *OpenFinanceFlow) handleRedirect(r *http.Request) (*OpenFinanceFlowResult, error) {
+	params, err := f.openFinance.ParseRedirectParams(r.URL.String())
+	if err != nil {
+		return nil, err
+	}
+
+	cookie, err := r.Cookie(f.CookieName)
+	if err != nil {
+		return nil, ErrFlowStateMismatch
+	}
+
+	sessionID, err := f.sessionID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Complete(sessionID, cookie.Value, params)
+}
+
+func (f *OpenFinanceFlow) sessionID(r *http.Request) (string, error) {
+	if f.SessionID == nil {
+		return "", nil
+	}
+
+	sessionID, err := f.SessionID(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve user session: %w", err)
+	}
+	return sessionID, nil
+}
+
+func newFlowToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate flow token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,60 @@
+package efi
+
+import (
+	"fmt"
+	"time"
+)
+
+type OpenFinanceFlowStatus string
+
+const (
+	FlowStatusStarted   OpenFinanceFlowStatus = "started"
+	FlowStatusPending   OpenFinanceFlowStatus = "pending"
+	FlowStatusCompleted OpenFinanceFlowStatus = "completed"
+	FlowStatusFailed    OpenFinanceFlowStatus = "failed"
+)
+
+type OpenFinanceFlowState struct {
+	PaymentID     string                   `json:"paymentId"`
+	SessionID     string                   `json:"sessionId,omitempty"`
+	Token         string                   `json:"token,omitempty"`
+	OwnID         string                   `json:"ownId,omitempty"`
+	RedirectURI   string                   `json:"redirectUri"`
+	Status        OpenFinanceFlowStatus    `json:"status"`
+	PaymentStatus OpenFinancePaymentStatus `json:"paymentStatus,omitempty"`
+	ErrorCode     string                   `json:"errorCode,omitempty"`
+	CreatedAt     time.Time                `json:"createdAt"`
+	ExpiresAt     time.Time                `json:"expiresAt"`
+	ReturnedAt    time.Time                `json:"returnedAt,omitempty"`
+}
+
+func (s *OpenFinanceFlowState) Expired(now time.Time) bool {
+	return !s.ExpiresAt.IsZero() && now.After(s.ExpiresAt)
+}
+
+type OpenFinanceRedirectError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Retry   bool   `json:"retry"`
+}
+
+func (e *OpenFinanceRedirectError) Error() string {
+	return fmt.Sprintf("open finance redirect error %s: %s", e.Code, e.Message)
+}
+
+type OpenFinanceFlowResult struct {
+	State   *OpenFinanceFlowState     `json:"state"`
+	Payment *OpenFinancePayment       `json:"payment,omitempty"`
+	Error   *OpenFinanceRedirectError `json:"error,omitempty"`
+}
+
+func (r *OpenFinanceFlowResult) public() *OpenFinanceFlowResult {
+	public := *r
+	if r.State != nil {
+		state := *r.State
+		state.Token = ""
+		state.SessionID = ""
+		public.State = &state
+	}
+	return &public
+}
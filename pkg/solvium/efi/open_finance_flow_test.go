@@ -0,0 +1,124 @@
+package efi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestOpenFinanceFlowRedirect(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			json.NewEncoder(w).Encode(OpenFinancePaymentResponse{PaymentID: "urn:efi:1", RedirectURI: "https://bank.example/authorize"})
+		default:
+			json.NewEncoder(w).Encode(OpenFinancePayment{PaymentID: "urn:efi:1", Status: PaymentStatusAccepted})
+		}
+	}))
+
+	flow := client.OpenFinance().Flow(NewMemoryOpenFinanceFlowStore())
+	flow.SessionID = func(r *http.Request) (string, error) {
+		return r.Header.Get("X-Session"), nil
+	}
+
+	rec := httptest.NewRecorder()
+	state, err := flow.Begin(rec, httptest.NewRequest(http.MethodGet, "/pay", nil), &OpenFinancePaymentRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cookie := rec.Result().Cookies()[0]
+
+	redirect := func(session, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/return?identificadorPagamento=urn:efi:1", nil)
+		req.Header.Set("X-Session", session)
+		req.AddCookie(&http.Cookie{Name: cookie.Name, Value: token})
+		rec := httptest.NewRecorder()
+		flow.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := redirect("", "forged"); rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for a forged token, got %d", rec.Code)
+	}
+	if rec := redirect("other-session", cookie.Value); rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for another session, got %d", rec.Code)
+	}
+
+	rec = redirect("", cookie.Value)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if strings.Contains(rec.Body.String(), state.Token) {
+		t.Fatalf("response leaks the flow token: %s", rec.Body.String())
+	}
+
+	var result OpenFinanceFlowResult
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if result.State.Status != FlowStatusCompleted {
+		t.Fatalf("expected completed flow, got %q", result.State.Status)
+	}
+
+	if rec := redirect("", cookie.Value); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a replayed redirect, got %d", rec.Code)
+	}
+	if _, err := flow.Complete("", cookie.Value, &OpenFinanceRedirectParams{PaymentIdentifier: "urn:efi:1"}); !errors.Is(err, ErrFlowStateConsumed) {
+		t.Fatalf("expected ErrFlowStateConsumed, got %v", err)
+	}
+}
+
+func TestOpenFinanceFlowCompleteSurvivesLookupFailureAndRacingReplays(t *testing.T) {
+	var failing int32 = 1
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			json.NewEncoder(w).Encode(OpenFinancePaymentResponse{PaymentID: "urn:efi:2"})
+			return
+		}
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(OpenFinancePayment{PaymentID: "urn:efi:2", Status: PaymentStatusAccepted})
+	}))
+
+	flow := client.OpenFinance().Flow(NewMemoryOpenFinanceFlowStore())
+	state, err := flow.Begin(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/pay", nil), &OpenFinancePaymentRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	params := &OpenFinanceRedirectParams{PaymentIdentifier: "urn:efi:2"}
+
+	if _, err := flow.Complete("", state.Token, params); err == nil || errors.Is(err, ErrFlowStateConsumed) {
+		t.Fatalf("expected the lookup error, got %v", err)
+	}
+	atomic.StoreInt32(&failing, 0)
+
+	var wg sync.WaitGroup
+	var completed, consumed int32
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := flow.Complete("", state.Token, params)
+			switch {
+			case err == nil:
+				atomic.AddInt32(&completed, 1)
+			case errors.Is(err, ErrFlowStateConsumed):
+				atomic.AddInt32(&consumed, 1)
+			default:
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if completed != 1 || consumed != 7 {
+		t.Fatalf("expected one completion and seven replays, got %d and %d", completed, consumed)
+	}
+}
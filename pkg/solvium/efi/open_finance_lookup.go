@@ -0,0 +1,243 @@
+package efi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type ListOpenFinancePaymentsOptions struct {
+	Status    OpenFinancePaymentStatus
+	PaymentID string
+	OwnID     string
+	Page      int
+	Limit     int
+}
+
+func (o *OpenFinance) GetPayment(paymentID string) (*OpenFinancePayment, error) {
+	resp, err := o.client.Request(http.MethodGet, fmt.Sprintf("/v1/pagamentos/pix/%s", url.PathEscape(paymentID)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get payment with status %d: %s", resp.StatusCode, body)
+	}
+
+	var payment OpenFinancePayment
+	if err := json.NewDecoder(resp.Body).Decode(&payment); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &payment, nil
+}
+
+func (o *OpenFinance) GetPaymentByOwnID(ownID string) (*OpenFinancePayment, error) {
+	resp, err := o.client.Request(http.MethodGet, fmt.Sprintf("/v1/pagamentos/pix/idProprio/%s", url.PathEscape(ownID)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment by own ID: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get payment by own ID with status %d: %s", resp.StatusCode, body)
+	}
+
+	var payment OpenFinancePayment
+	if err := json.NewDecoder(resp.Body).Decode(&payment); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &payment, nil
+}
+
+func (o *OpenFinance) GetScheduledPayment(paymentID string) (*OpenFinanceScheduledPayment, error) {
+	resp, err := o.client.Request(http.MethodGet, fmt.Sprintf("/v1/pagamentos-agendados/pix/%s", url.PathEscape(paymentID)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduled payment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get scheduled payment with status %d: %s", resp.StatusCode, body)
+	}
+
+	var payment OpenFinanceScheduledPayment
+	if err := json.NewDecoder(resp.Body).Decode(&payment); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &payment, nil
+}
+
+func (o *OpenFinance) GetScheduledPaymentByOwnID(ownID string) (*OpenFinanceScheduledPayment, error) {
+	resp, err := o.client.Request(http.MethodGet, fmt.Sprintf("/v1/pagamentos-agendados/pix/idProprio/%s", url.PathEscape(ownID)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduled payment by own ID: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get scheduled payment by own ID with status %d: %s", resp.StatusCode, body)
+	}
+
+	var payment OpenFinanceScheduledPayment
+	if err := json.NewDecoder(resp.Body).Decode(&payment); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &payment, nil
+}
+
+func (o *OpenFinance) ListPaymentsByPeriod(startDate, endDate time.Time, options *ListOpenFinancePaymentsOptions) (*OpenFinancePaymentList, error) {
+	path := fmt.Sprintf("/v1/pagamentos/pix?%s", openFinanceListQuery(startDate, endDate, options).Encode())
+
+	resp, err := o.client.Request(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to list payments with status %d: %s", resp.StatusCode, body)
+	}
+
+	var paymentList OpenFinancePaymentList
+	if err := json.NewDecoder(resp.Body).Decode(&paymentList); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &paymentList, nil
+}
+
+func (o *OpenFinance) ListScheduledPaymentsByPeriod(startDate, endDate time.Time, options *ListOpenFinancePaymentsOptions) (*OpenFinanceScheduledPaymentList, error) {
+	path := fmt.Sprintf("/v1/pagamentos-agendados/pix?%s", openFinanceListQuery(startDate, endDate, options).Encode())
+
+	resp, err := o.client.Request(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled payments: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to list scheduled payments with status %d: %s", resp.StatusCode, body)
+	}
+
+	var paymentList OpenFinanceScheduledPaymentList
+	if err := json.NewDecoder(resp.Body).Decode(&paymentList); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &paymentList, nil
+}
+
+func (o *OpenFinance) IteratePayments(startDate, endDate time.Time, options *ListOpenFinancePaymentsOptions) *Iterator[OpenFinancePayment] {
+	next := fmt.Sprintf("/v1/pagamentos/pix?%s", openFinanceListQuery(startDate, endDate, options).Encode())
+
+	return newIterator(func(ctx context.Context) ([]OpenFinancePayment, bool, error) {
+		resp, err := o.client.Request(http.MethodGet, next, nil)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to list payments: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			return nil, false, fmt.Errorf("failed to list payments with status %d: %s", resp.StatusCode, body)
+		}
+
+		var page OpenFinancePaymentList
+		if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+			return nil, false, fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		link, more, err := followLink(o.client.BaseURL, next, page.Next, page.Current)
+		next = link
+		return page.Payments, more, err
+	})
+}
+
+func (o *OpenFinance) IterateScheduledPayments(startDate, endDate time.Time, options *ListOpenFinancePaymentsOptions) *Iterator[OpenFinanceScheduledPayment] {
+	next := fmt.Sprintf("/v1/pagamentos-agendados/pix?%s", openFinanceListQuery(startDate, endDate, options).Encode())
+
+	return newIterator(func(ctx context.Context) ([]OpenFinanceScheduledPayment, bool, error) {
+		resp, err := o.client.Request(http.MethodGet, next, nil)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to list scheduled payments: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			return nil, false, fmt.Errorf("failed to list scheduled payments with status %d: %s", resp.StatusCode, body)
+		}
+
+		var page OpenFinanceScheduledPaymentList
+		if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+			return nil, false, fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		link, more, err := followLink(o.client.BaseURL, next, page.Next, page.Current)
+		next = link
+		return page.Payments, more, err
+	})
+}
+
+func followLink(baseURL, requested, next, current string) (string, bool, error) {
+	if next == "" || next == current {
+		return "", false, nil
+	}
+
+	base, err := url.Parse(baseURL + requested)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid pagination base %q: %w", requested, err)
+	}
+	link, err := url.Parse(next)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid pagination link %q: %w", next, err)
+	}
+
+	resolved := base.ResolveReference(link)
+	if resolved.Host != base.Host {
+		return "", false, fmt.Errorf("pagination link %q points outside %s", next, base.Host)
+	}
+
+	return resolved.RequestURI(), true, nil
+}
+
+func openFinanceListQuery(startDate, endDate time.Time, options *ListOpenFinancePaymentsOptions) url.Values {
+	query := url.Values{}
+	query.Add("inicio", startDate.Format("2006-01-02"))
+	query.Add("fim", endDate.Format("2006-01-02"))
+
+	if options != nil {
+		if options.Status != "" {
+			query.Add("status", string(options.Status))
+		}
+		if options.PaymentID != "" {
+			query.Add("identificadorPagamento", options.PaymentID)
+		}
+		if options.OwnID != "" {
+			query.Add("idProprio", options.OwnID)
+		}
+		if options.Page > 0 {
+			query.Add("pagina", fmt.Sprintf("%d", options.Page))
+		}
+		if options.Limit > 0 {
+			query.Add("quantidade", fmt.Sprintf("%d", options.Limit))
+		}
+	}
+
+	return query
+}
@@ -0,0 +1,91 @@
+package efi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOpenFinanceIteratePaymentsFollowsLinks(t *testing.T) {
+	var serverURL string
+	var requested []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.RequestURI())
+		if r.URL.Path != "/v1/pagamentos/pix" {
+			http.NotFound(w, r)
+			return
+		}
+
+		switch r.URL.Query().Get("pagina") {
+		case "":
+			json.NewEncoder(w).Encode(OpenFinancePaymentList{Payments: []OpenFinancePayment{{PaymentID: "p1"}}, Next: "?pagina=2"})
+		case "2":
+			json.NewEncoder(w).Encode(OpenFinancePaymentList{Payments: []OpenFinancePayment{{PaymentID: "p2"}}, Next: serverURL + "/v1/pagamentos/pix?pagina=3"})
+		case "3":
+			json.NewEncoder(w).Encode(OpenFinancePaymentList{Payments: []OpenFinancePayment{{PaymentID: "p3"}}, Current: "/v1/pagamentos/pix?pagina=3", Next: "/v1/pagamentos/pix?pagina=3"})
+		}
+	}))
+	serverURL = client.BaseURL
+
+	start := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	payments, err := client.OpenFinance().IteratePayments(start, start.AddDate(0, 1, 0), nil).All(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(payments) != 3 || payments[2].PaymentID != "p3" {
+		t.Fatalf("unexpected payments %+v", payments)
+	}
+	if requested[1] != "/v1/pagamentos/pix?pagina=2" || requested[2] != "/v1/pagamentos/pix?pagina=3" {
+		t.Fatalf("unexpected requests %v", requested)
+	}
+}
+
+func TestOpenFinanceIterateScheduledPaymentsRejectsForeignLinks(t *testing.T) {
+	var requested []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.RequestURI())
+		if r.URL.Path != "/v1/pagamentos-agendados/pix" {
+			http.NotFound(w, r)
+			return
+		}
+
+		switch r.URL.Query().Get("pagina") {
+		case "":
+			json.NewEncoder(w).Encode(OpenFinanceScheduledPaymentList{Payments: []OpenFinanceScheduledPayment{{PaymentID: "s1"}}, Next: "pix?pagina=2"})
+		case "2":
+			json.NewEncoder(w).Encode(OpenFinanceScheduledPaymentList{Payments: []OpenFinanceScheduledPayment{{PaymentID: "s2"}}, Next: "https://attacker.example/v1/pagamentos-agendados/pix?pagina=3"})
+		}
+	}))
+
+	start := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := client.OpenFinance().IterateScheduledPayments(start, start.AddDate(0, 1, 0), nil).All(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "points outside") {
+		t.Fatalf("expected a foreign link to be rejected, got %v", err)
+	}
+	if len(requested) != 2 || requested[1] != "/v1/pagamentos-agendados/pix?pagina=2" {
+		t.Fatalf("unexpected requests %v", requested)
+	}
+}
+
+func TestOpenFinanceGetPaymentByOwnID(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/v1/pagamentos/pix/idProprio/order%2F1" {
+			http.Error(w, `{"nome":"pagamento_nao_encontrado"}`, http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(OpenFinancePayment{PaymentID: "urn:efi:1", OwnID: "order/1"})
+	}))
+
+	payment, err := client.OpenFinance().GetPaymentByOwnID("order/1")
+	if err != nil || payment.PaymentID != "urn:efi:1" {
+		t.Fatalf("unexpected payment %+v, err %v", payment, err)
+	}
+
+	if _, err := client.OpenFinance().GetPaymentByOwnID("order-2"); err == nil || !strings.Contains(err.Error(), "status 404") {
+		t.Fatalf("expected a 404 error, got %v", err)
+	}
+}
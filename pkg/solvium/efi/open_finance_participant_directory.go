@@ -0,0 +1,396 @@
+package efi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+const DefaultParticipantDirectoryTTL = 6 * time.Hour
+
+var DefaultActiveOrganizationStatuses = []string{"active", "ativo", "ativa"}
+
+type ParticipantEntry struct {
+	ID            string                    `json:"id"`
+	Name          string                    `json:"name"`
+	Description   string                    `json:"description,omitempty"`
+	Logo          string                    `json:"logo,omitempty"`
+	Portal        string                    `json:"portal,omitempty"`
+	Organizations []OpenFinanceOrganization `json:"organizations"`
+}
+
+type participantIndex struct {
+	entry ParticipantEntry
+	name  string
+	words []string
+	cnpjs []string
+}
+
+type ParticipantDirectory struct {
+	openFinance *OpenFinance
+
+	Request        *OpenFinanceParticipantRequest
+	TTL            time.Duration
+	ActiveStatuses []string
+
+	mu         sync.RWMutex
+	index      []participantIndex
+	loadedAt   time.Time
+	refreshing bool
+	lastErr    error
+}
+
+func NewParticipantDirectory(openFinance *OpenFinance) *ParticipantDirectory {
+	return &ParticipantDirectory{
+		openFinance:    openFinance,
+		Request:        &OpenFinanceParticipantRequest{Organization: true},
+		TTL:            DefaultParticipantDirectoryTTL,
+		ActiveStatuses: DefaultActiveOrganizationStatuses,
+	}
+}
+
+func (o *OpenFinance) ParticipantDirectory() *ParticipantDirectory {
+	return NewParticipantDirectory(o)
+}
+
+func (d *ParticipantDirectory) Refresh() error {
+	request := OpenFinanceParticipantRequest{}
+	if d.Request != nil {
+		request = *d.Request
+	}
+	request.Organization = true
+
+	response, err := d.openFinance.GetParticipants(&request)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.refreshing = false
+
+	if err != nil {
+		d.lastErr = err
+		return err
+	}
+
+	index := make([]participantIndex, 0, len(response.Participants))
+	for _, participant := range response.Participants {
+		organizations := d.activeOrganizations(participant.Organizations)
+		if len(participant.Organizations) > 0 && len(organizations) == 0 {
+			continue
+		}
+
+		item := participantIndex{
+			entry: ParticipantEntry{
+				ID:            participant.ID,
+				Name:          participant.Name,
+				Description:   participant.Description,
+				Logo:          participant.Logo,
+				Portal:        participant.Portal,
+				Organizations: organizations,
+			},
+			name: normalizeSearchText(participant.Name),
+		}
+		item.words = strings.Fields(item.name)
+		for _, organization := range organizations {
+			item.words = append(item.words, strings.Fields(normalizeSearchText(organization.Name))...)
+			if cnpj := onlyDigits(organization.CNPJ); cnpj != "" {
+				item.cnpjs = append(item.cnpjs, cnpj)
+			}
+		}
+
+		index = append(index, item)
+	}
+
+	sort.Slice(index, func(i, j int) bool {
+		return index[i].name < index[j].name
+	})
+
+	d.index = index
+	d.loadedAt = time.Now()
+	d.lastErr = nil
+	return nil
+}
+
+func (d *ParticipantDirectory) Start(ctx context.Context) {
+	go func() {
+		if err := d.Refresh(); err != nil {
+			d.recordError(err)
+		}
+
+		ticker := time.NewTicker(d.ttl())
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := d.Refresh(); err != nil {
+					d.recordError(err)
+				}
+			}
+		}
+	}()
+}
+
+func (d *ParticipantDirectory) Err() error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.lastErr
+}
+
+func (d *ParticipantDirectory) Participants() ([]ParticipantEntry, error) {
+	index, err := d.load()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]ParticipantEntry, len(index))
+	for i, item := range index {
+		entries[i] = item.entry
+	}
+	return entries, nil
+}
+
+func (d *ParticipantDirectory) Get(id string) (ParticipantEntry, bool, error) {
+	index, err := d.load()
+	if err != nil {
+		return ParticipantEntry{}, false, err
+	}
+
+	for _, item := range index {
+		if item.entry.ID == id {
+			return item.entry, true, nil
+		}
+	}
+	return ParticipantEntry{}, false, nil
+}
+
+func (d *ParticipantDirectory) Search(query string, limit int) ([]ParticipantEntry, error) {
+	index, err := d.load()
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := normalizeSearchText(query)
+	digits := onlyDigits(query)
+	if normalized == "" {
+		entries := make([]ParticipantEntry, 0, len(index))
+		for _, item := range index {
+			entries = append(entries, item.entry)
+		}
+		return truncateParticipants(entries, limit), nil
+	}
+
+	type scored struct {
+		entry ParticipantEntry
+		score int
+	}
+
+	var matches []scored
+	for _, item := range index {
+		score := scoreParticipant(item, normalized, digits)
+		if score > 0 {
+			matches = append(matches, scored{entry: item.entry, score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	entries := make([]ParticipantEntry, len(matches))
+	for i, match := range matches {
+		entries[i] = match.entry
+	}
+	return truncateParticipants(entries, limit), nil
+}
+
+func (d *ParticipantDirectory) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	entries, err := d.Search(r.URL.Query().Get("q"), limit)
+	if err != nil {
+		http.Error(w, "participant directory unavailable", http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	json.NewEncoder(w).Encode(entries)
+}
+
+func (d *ParticipantDirectory) load() ([]participantIndex, error) {
+	d.mu.Lock()
+	loaded := !d.loadedAt.IsZero()
+	stale := loaded && time.Since(d.loadedAt) > d.ttl()
+	if stale && !d.refreshing {
+		d.refreshing = true
+		go d.Refresh()
+	}
+	index := d.index
+	d.mu.Unlock()
+
+	if loaded {
+		return index, nil
+	}
+
+	if err := d.Refresh(); err != nil {
+		return nil, err
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.index, nil
+}
+
+func (d *ParticipantDirectory) activeOrganizations(organizations []OpenFinanceOrganization) []OpenFinanceOrganization {
+	active := make([]OpenFinanceOrganization, 0, len(organizations))
+	for _, organization := range organizations {
+		status := strings.ToLower(strings.TrimSpace(organization.Status))
+		for _, allowed := range d.ActiveStatuses {
+			if status == allowed {
+				active = append(active, organization)
+				break
+			}
+		}
+	}
+	return active
+}
+
+func (d *ParticipantDirectory) recordError(err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.lastErr = err
+}
+
+func (d *ParticipantDirectory) ttl() time.Duration {
+	if d.TTL <= 0 {
+		return DefaultParticipantDirectoryTTL
+	}
+	return d.TTL
+}
+
+func scoreParticipant(item participantIndex, query, digits string) int {
+	if len(digits) >= 3 {
+		for _, cnpj := range item.cnpjs {
+			if strings.HasPrefix(cnpj, digits) {
+				return 100
+			}
+			if strings.Contains(cnpj, digits) {
+				return 70
+			}
+		}
+	}
+
+	switch {
+	case item.name == query:
+		return 100
+	case strings.HasPrefix(item.name, query):
+		return 90
+	case strings.Contains(item.name, query):
+		return 75
+	}
+
+	terms := strings.Fields(query)
+	score := 0
+	for _, term := range terms {
+		best := 0
+		for _, word := range item.words {
+			switch {
+			case strings.HasPrefix(word, term):
+				best = maxInt(best, 3)
+			case len(term) >= 4 && withinOneEdit(word, term):
+				best = maxInt(best, 2)
+			case len(term) >= 3 && strings.Contains(word, term):
+				best = maxInt(best, 1)
+			}
+		}
+		if best == 0 {
+			return 0
+		}
+		score += best
+	}
+
+	return 10 + score*10/len(terms)
+}
+
+func withinOneEdit(a, b string) bool {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra
+	}
+	if len(ra)-len(rb) > 1 {
+		return false
+	}
+
+	i, j, edits := 0, 0, 0
+	for i < len(ra) && j < len(rb) {
+		if ra[i] == rb[j] {
+			i++
+			j++
+			continue
+		}
+		edits++
+		if edits > 1 {
+			return false
+		}
+		if len(ra) == len(rb) {
+			j++
+		}
+		i++
+	}
+	return edits+(len(ra)-i) <= 1
+}
+
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+func normalizeSearchText(value string) string {
+	value = accentReplacer.Replace(strings.ToLower(value))
+
+	var b strings.Builder
+	for _, r := range value {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func onlyDigits(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func truncateParticipants(entries []ParticipantEntry, limit int) []ParticipantEntry {
+	if limit > 0 && len(entries) > limit {
+		return entries[:limit]
+	}
+	return entries
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,66 @@
+package efi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestParticipantDirectorySearch(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("organizacao") != "true" {
+			t.Errorf("expected organizacao=true, got %q", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(OpenFinanceParticipantResponse{Participants: []OpenFinanceParticipant{
+			{ID: "1", Name: "Banco São João", Organizations: []OpenFinanceOrganization{{Name: "São João S.A.", CNPJ: "11.222.333/0001-81", Status: "Active"}}},
+			{ID: "2", Name: "Cooperativa Crédito", Organizations: []OpenFinanceOrganization{{Name: "Coop", CNPJ: "44.555.666/0001-00", Status: "Ativo"}}},
+			{ID: "3", Name: "Banco Encerrado", Organizations: []OpenFinanceOrganization{{Name: "Encerrado", CNPJ: "77.888.999/0001-00", Status: "Inactive"}}},
+		}})
+	}))
+
+	directory := client.OpenFinance().ParticipantDirectory()
+
+	tests := []struct {
+		query string
+		ids   []string
+	}{
+		{"sao joao", []string{"1"}},
+		{"BANCO", []string{"1"}},
+		{"credito", []string{"2"}},
+		{"cooperatva", []string{"2"}},
+		{"44.555", []string{"2"}},
+		{"11222333000181", []string{"1"}},
+		{"encerrado", nil},
+	}
+
+	for _, tt := range tests {
+		entries, err := directory.Search(tt.query, 0)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.query, err)
+		}
+		if len(entries) != len(tt.ids) {
+			t.Errorf("%s: expected %v, got %+v", tt.query, tt.ids, entries)
+			continue
+		}
+		for i, id := range tt.ids {
+			if entries[i].ID != id {
+				t.Errorf("%s: expected %v, got %+v", tt.query, tt.ids, entries)
+			}
+		}
+	}
+}
+
+func TestNormalizeSearchText(t *testing.T) {
+	tests := map[string]string{
+		"Banco São João":     "banco sao joao",
+		"  CRÉDITO--Coop.  ": "credito coop",
+		"11.222.333/0001-81": "11 222 333 0001 81",
+		"Ação & Ñandú Ltda.": "acao nandu ltda",
+	}
+
+	for input, expected := range tests {
+		if got := normalizeSearchText(input); got != expected {
+			t.Errorf("normalizeSearchText(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
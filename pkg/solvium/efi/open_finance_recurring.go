@@ -0,0 +1,343 @@
+package efi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+func (r OpenFinanceRecurrence) Validate() error {
+	switch r.Type {
+	case RecurrenceDaily:
+	case RecurrenceWeekly:
+		if r.DayOfWeek == "" {
+			return fmt.Errorf("weekly recurrence requires diaDaSemana")
+		}
+	case RecurrenceMonthly:
+		if r.DayOfMonth < 1 || r.DayOfMonth > 31 {
+			return fmt.Errorf("monthly recurrence requires diaDoMes between 1 and 31")
+		}
+	case RecurrenceCustom:
+		if len(r.Dates) == 0 {
+			return fmt.Errorf("custom recurrence requires at least one date")
+		}
+		for _, date := range r.Dates {
+			if _, err := time.Parse("2006-01-02", date); err != nil {
+				return fmt.Errorf("invalid recurrence date %q: %w", date, err)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported recurrence type %q", r.Type)
+	}
+
+	if r.StartDate == "" {
+		return fmt.Errorf("%s recurrence requires dataInicio", r.Type)
+	}
+	if _, err := time.Parse("2006-01-02", r.StartDate); err != nil {
+		return fmt.Errorf("invalid recurrence start date %q: %w", r.StartDate, err)
+	}
+	if r.Quantity <= 0 {
+		return fmt.Errorf("%s recurrence requires a positive quantidade", r.Type)
+	}
+
+	return nil
+}
+
+func (o *OpenFinance) InitiateRecurringPayment(request *OpenFinanceRecurringPaymentRequest) (*OpenFinancePaymentResponse, error) {
+	if request == nil {
+		return nil, errors.New("recurring payment request is required")
+	}
+	if err := request.Payment.Recurrence.Validate(); err != nil {
+		return nil, err
+	}
+
+	adjusted := *request
+	recurrence := &adjusted.Payment.Recurrence
+
+	if recurrence.StartDate != "" && recurrence.Type != RecurrenceWeekly {
+		startDate, err := o.client.adjustScheduledDate(recurrence.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to adjust recurrence start date: %w", err)
+		}
+		recurrence.StartDate = startDate
+	}
+
+	if len(recurrence.Dates) > 0 {
+		dates := make([]string, len(recurrence.Dates))
+		rolled := make(map[string]string, len(recurrence.Dates))
+		for i, date := range recurrence.Dates {
+			adjustedDate, err := o.client.adjustScheduledDate(date)
+			if err != nil {
+				return nil, fmt.Errorf("failed to adjust recurrence date: %w", err)
+			}
+			if previous, ok := rolled[adjustedDate]; ok {
+				return nil, fmt.Errorf("recurrence dates %s and %s both fall on business day %s", previous, date, adjustedDate)
+			}
+			rolled[adjustedDate] = date
+			dates[i] = adjustedDate
+		}
+		recurrence.Dates = dates
+	}
+
+	payload, err := json.Marshal(adjusted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := o.client.Request(http.MethodPost, "/v1/pagamentos-recorrentes/pix", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate recurring payment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to initiate recurring payment with status %d: %s", resp.StatusCode, body)
+	}
+
+	var paymentResponse OpenFinancePaymentResponse
+	if err := json.NewDecoder(resp.Body).Decode(&paymentResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &paymentResponse, nil
+}
+
+func (o *OpenFinance) GetRecurringPayment(paymentID string) (*OpenFinanceRecurringPayment, error) {
+	resp, err := o.client.Request(http.MethodGet, fmt.Sprintf("/v1/pagamentos-recorrentes/pix/%s", url.PathEscape(paymentID)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring payment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get recurring payment with status %d: %s", resp.StatusCode, body)
+	}
+
+	var payment OpenFinanceRecurringPayment
+	if err := json.NewDecoder(resp.Body).Decode(&payment); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &payment, nil
+}
+
+func (o *OpenFinance) ListRecurringPayments(startDate, endDate time.Time, options *ListOpenFinancePaymentsOptions) (*OpenFinanceRecurringPaymentList, error) {
+	path := fmt.Sprintf("/v1/pagamentos-recorrentes/pix?%s", openFinanceListQuery(startDate, endDate, options).Encode())
+
+	resp, err := o.client.Request(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurring payments: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to list recurring payments with status %d: %s", resp.StatusCode, body)
+	}
+
+	var paymentList OpenFinanceRecurringPaymentList
+	if err := json.NewDecoder(resp.Body).Decode(&paymentList); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &paymentList, nil
+}
+
+func (o *OpenFinance) IterateRecurringPayments(startDate, endDate time.Time, options *ListOpenFinancePaymentsOptions) *Iterator[OpenFinanceRecurringPayment] {
+	next := fmt.Sprintf("/v1/pagamentos-recorrentes/pix?%s", openFinanceListQuery(startDate, endDate, options).Encode())
+
+	return newIterator(func(ctx context.Context) ([]OpenFinanceRecurringPayment, bool, error) {
+		resp, err := o.client.Request(http.MethodGet, next, nil)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to list recurring payments: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			return nil, false, fmt.Errorf("failed to list recurring payments with status %d: %s", resp.StatusCode, body)
+		}
+
+		var page OpenFinanceRecurringPaymentList
+		if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+			return nil, false, fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		link, more, err := followLink(o.client.BaseURL, next, page.Next, page.Current)
+		next = link
+		return page.Payments, more, err
+	})
+}
+
+func (o *OpenFinance) CancelRecurringPayment(paymentID string) (*OpenFinanceRecurringCancellationResponse, error) {
+	return o.cancelRecurring(paymentID, OpenFinanceRecurringCancellationRequest{}, "cancel recurring payment")
+}
+
+func (o *OpenFinance) CancelRecurringOccurrence(paymentID, endToEndID string) (*OpenFinanceRecurringCancellationResponse, error) {
+	if endToEndID == "" {
+		return nil, fmt.Errorf("endToEndId is required to cancel a single occurrence")
+	}
+
+	return o.cancelRecurring(paymentID, OpenFinanceRecurringCancellationRequest{EndToEndID: endToEndID}, "cancel recurring payment occurrence")
+}
+
+func (o *OpenFinance) RefundRecurringPayment(paymentID, endToEndID, value string) (*OpenFinanceRefundResponse, error) {
+	request := &OpenFinanceScheduledRefundRequest{
+		EndToEndID: endToEndID,
+		Value:      value,
+	}
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	path := fmt.Sprintf("/v1/pagamentos-recorrentes/pix/%s/devolver", url.PathEscape(paymentID))
+	resp, err := o.client.Request(http.MethodPost, path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate recurring payment refund: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to initiate recurring payment refund with status %d: %s", resp.StatusCode, body)
+	}
+
+	var refundResponse OpenFinanceRefundResponse
+	if err := json.NewDecoder(resp.Body).Decode(&refundResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &refundResponse, nil
+}
+
+func (o *OpenFinance) CreateSweepingConsent(request *OpenFinanceSweepingConsentRequest) (*OpenFinanceSweepingConsentResponse, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := o.client.Request(http.MethodPost, "/v1/consentimentos-varredura/pix", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sweeping consent: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to create sweeping consent with status %d: %s", resp.StatusCode, body)
+	}
+
+	var consentResponse OpenFinanceSweepingConsentResponse
+	if err := json.NewDecoder(resp.Body).Decode(&consentResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &consentResponse, nil
+}
+
+func (o *OpenFinance) GetSweepingConsent(consentID string) (*OpenFinanceSweepingConsent, error) {
+	resp, err := o.client.Request(http.MethodGet, fmt.Sprintf("/v1/consentimentos-varredura/pix/%s", url.PathEscape(consentID)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sweeping consent: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get sweeping consent with status %d: %s", resp.StatusCode, body)
+	}
+
+	var consent OpenFinanceSweepingConsent
+	if err := json.NewDecoder(resp.Body).Decode(&consent); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &consent, nil
+}
+
+func (o *OpenFinance) RevokeSweepingConsent(consentID string) (*OpenFinanceSweepingConsent, error) {
+	path := fmt.Sprintf("/v1/consentimentos-varredura/pix/%s/revogar", url.PathEscape(consentID))
+	resp, err := o.client.Request(http.MethodPatch, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke sweeping consent: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to revoke sweeping consent with status %d: %s", resp.StatusCode, body)
+	}
+
+	var consent OpenFinanceSweepingConsent
+	if err := json.NewDecoder(resp.Body).Decode(&consent); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &consent, nil
+}
+
+func (o *OpenFinance) InitiateSweepingPayment(request *OpenFinanceSweepingPaymentRequest) (*OpenFinancePayment, error) {
+	if request.ConsentID == "" {
+		return nil, fmt.Errorf("identificadorConsentimento is required for sweeping payments")
+	}
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := o.client.Request(http.MethodPost, "/v1/pagamentos-varredura/pix", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate sweeping payment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to initiate sweeping payment with status %d: %s", resp.StatusCode, body)
+	}
+
+	var payment OpenFinancePayment
+	if err := json.NewDecoder(resp.Body).Decode(&payment); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &payment, nil
+}
+
+func (o *OpenFinance) cancelRecurring(paymentID string, request OpenFinanceRecurringCancellationRequest, action string) (*OpenFinanceRecurringCancellationResponse, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	path := fmt.Sprintf("/v1/pagamentos-recorrentes/pix/%s/cancelar", url.PathEscape(paymentID))
+	resp, err := o.client.Request(http.MethodPatch, path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to %s with status %d: %s", action, resp.StatusCode, body)
+	}
+
+	var cancellationResponse OpenFinanceRecurringCancellationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&cancellationResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &cancellationResponse, nil
+}
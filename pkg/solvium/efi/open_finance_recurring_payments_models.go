@@ -0,0 +1,139 @@
+package efi
+
+type OpenFinanceRecurrenceType string
+
+const (
+	RecurrenceDaily   OpenFinanceRecurrenceType = "diaria"
+	RecurrenceWeekly  OpenFinanceRecurrenceType = "semanal"
+	RecurrenceMonthly OpenFinanceRecurrenceType = "mensal"
+	RecurrenceCustom  OpenFinanceRecurrenceType = "personalizada"
+)
+
+type OpenFinanceWeekday string
+
+const (
+	WeekdayMonday    OpenFinanceWeekday = "SEGUNDA_FEIRA"
+	WeekdayTuesday   OpenFinanceWeekday = "TERCA_FEIRA"
+	WeekdayWednesday OpenFinanceWeekday = "QUARTA_FEIRA"
+	WeekdayThursday  OpenFinanceWeekday = "QUINTA_FEIRA"
+	WeekdayFriday    OpenFinanceWeekday = "SEXTA_FEIRA"
+	WeekdaySaturday  OpenFinanceWeekday = "SABADO"
+	WeekdaySunday    OpenFinanceWeekday = "DOMINGO"
+)
+
+type OpenFinanceRecurrence struct {
+	Type       OpenFinanceRecurrenceType `json:"tipo"`
+	StartDate  string                    `json:"dataInicio,omitempty"`
+	Quantity   int                       `json:"quantidade,omitempty"`
+	DayOfWeek  OpenFinanceWeekday        `json:"diaDaSemana,omitempty"`
+	DayOfMonth int                       `json:"diaDoMes,omitempty"`
+	Dates      []string                  `json:"datas,omitempty"`
+}
+
+type OpenFinanceRecurringPaymentInfo struct {
+	Value      string                `json:"valor"`
+	PayerInfo  string                `json:"infoPagador,omitempty"`
+	OwnID      string                `json:"idProprio,omitempty"`
+	Recurrence OpenFinanceRecurrence `json:"recorrencia"`
+}
+
+type OpenFinanceRecurringPaymentRequest struct {
+	Payer     OpenFinancePaymentPayer         `json:"pagador"`
+	Recipient OpenFinanceRecipient            `json:"favorecido"`
+	Payment   OpenFinanceRecurringPaymentInfo `json:"pagamento"`
+}
+
+type OpenFinanceRecurringOccurrence struct {
+	EndToEndID    string                   `json:"endToEndId"`
+	Value         string                   `json:"valor"`
+	Status        OpenFinancePaymentStatus `json:"status"`
+	ScheduledDate string                   `json:"dataAgendamento"`
+	OperationDate string                   `json:"dataOperacao,omitempty"`
+	Refunds       []OpenFinanceRefund      `json:"devolucoes,omitempty"`
+}
+
+type OpenFinanceRecurringPayment struct {
+	PaymentID   string                           `json:"identificadorPagamento"`
+	OwnID       string                           `json:"idProprio,omitempty"`
+	Value       string                           `json:"valor"`
+	Status      OpenFinancePaymentStatus         `json:"status"`
+	Recurrence  OpenFinanceRecurrence            `json:"recorrencia"`
+	CreatedAt   string                           `json:"dataCriacao"`
+	Occurrences []OpenFinanceRecurringOccurrence `json:"ocorrencias,omitempty"`
+}
+
+type OpenFinanceRecurringPaymentList struct {
+	Payments []OpenFinanceRecurringPayment `json:"pagamentos"`
+	Total    int                           `json:"total"`
+	PerPage  int                           `json:"porPagina"`
+	Last     string                        `json:"ultimo"`
+	Next     string                        `json:"proximo"`
+	Previous string                        `json:"anterior"`
+	Current  string                        `json:"atual"`
+}
+
+type OpenFinanceRecurringCancellationRequest struct {
+	EndToEndID string `json:"endToEndId,omitempty"`
+}
+
+type OpenFinanceRecurringCancellationResponse struct {
+	PaymentID        string                   `json:"identificadorPagamento"`
+	EndToEndID       string                   `json:"endToEndId,omitempty"`
+	Status           OpenFinancePaymentStatus `json:"status"`
+	CancellationDate string                   `json:"dataCancelamento"`
+}
+
+type OpenFinanceConsentStatus string
+
+const (
+	ConsentStatusAwaitingAuthorization OpenFinanceConsentStatus = "aguardando_autorizacao"
+	ConsentStatusAuthorized            OpenFinanceConsentStatus = "autorizado"
+	ConsentStatusRejected              OpenFinanceConsentStatus = "rejeitado"
+	ConsentStatusRevoked               OpenFinanceConsentStatus = "revogado"
+	ConsentStatusConsumed              OpenFinanceConsentStatus = "consumido"
+)
+
+type OpenFinancePeriodicLimit struct {
+	Value    string `json:"valorMaximo,omitempty"`
+	Quantity int    `json:"quantidadeMaxima,omitempty"`
+}
+
+type OpenFinanceSweepingLimits struct {
+	TotalValue       string                    `json:"valorMaximoTotal,omitempty"`
+	TransactionValue string                    `json:"valorMaximoTransacao,omitempty"`
+	Day              *OpenFinancePeriodicLimit `json:"dia,omitempty"`
+	Week             *OpenFinancePeriodicLimit `json:"semana,omitempty"`
+	Month            *OpenFinancePeriodicLimit `json:"mes,omitempty"`
+	Year             *OpenFinancePeriodicLimit `json:"ano,omitempty"`
+}
+
+type OpenFinanceSweepingConsentRequest struct {
+	Payer          OpenFinancePaymentPayer   `json:"pagador"`
+	Recipient      OpenFinanceRecipient      `json:"favorecido"`
+	Limits         OpenFinanceSweepingLimits `json:"limites"`
+	StartDate      string                    `json:"dataInicio,omitempty"`
+	ExpirationDate string                    `json:"dataExpiracao,omitempty"`
+	OwnID          string                    `json:"idProprio,omitempty"`
+}
+
+type OpenFinanceSweepingConsentResponse struct {
+	ConsentID   string `json:"identificadorConsentimento"`
+	RedirectURI string `json:"redirectURI"`
+}
+
+type OpenFinanceSweepingConsent struct {
+	ConsentID      string                    `json:"identificadorConsentimento"`
+	Status         OpenFinanceConsentStatus  `json:"status"`
+	Limits         OpenFinanceSweepingLimits `json:"limites"`
+	StartDate      string                    `json:"dataInicio,omitempty"`
+	ExpirationDate string                    `json:"dataExpiracao,omitempty"`
+	OwnID          string                    `json:"idProprio,omitempty"`
+	CreatedAt      string                    `json:"dataCriacao"`
+}
+
+type OpenFinanceSweepingPaymentRequest struct {
+	ConsentID string `json:"identificadorConsentimento"`
+	Value     string `json:"valor"`
+	PayerInfo string `json:"infoPagador,omitempty"`
+	OwnID     string `json:"idProprio,omitempty"`
+}
@@ -0,0 +1,116 @@
+package efi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/solviumdream/solviumpayments/pkg/solvium/calendar"
+)
+
+func TestOpenFinanceRecurrenceValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		recurrence OpenFinanceRecurrence
+		valid      bool
+	}{
+		{"daily", OpenFinanceRecurrence{Type: RecurrenceDaily, StartDate: "2030-01-14", Quantity: 3}, true},
+		{"daily without start", OpenFinanceRecurrence{Type: RecurrenceDaily, Quantity: 3}, false},
+		{"weekly without weekday", OpenFinanceRecurrence{Type: RecurrenceWeekly, StartDate: "2030-01-14", Quantity: 3}, false},
+		{"monthly out of range", OpenFinanceRecurrence{Type: RecurrenceMonthly, StartDate: "2030-01-14", Quantity: 3, DayOfMonth: 32}, false},
+		{"monthly without quantity", OpenFinanceRecurrence{Type: RecurrenceMonthly, StartDate: "2030-01-14", DayOfMonth: 5}, false},
+		{"custom", OpenFinanceRecurrence{Type: RecurrenceCustom, Dates: []string{"2030-01-14"}}, true},
+		{"custom with bad date", OpenFinanceRecurrence{Type: RecurrenceCustom, Dates: []string{"14/01/2030"}}, false},
+		{"unknown", OpenFinanceRecurrence{Type: "anual"}, false},
+	}
+
+	for _, tt := range tests {
+		if err := tt.recurrence.Validate(); (err == nil) != tt.valid {
+			t.Errorf("%s: expected valid %v, got %v", tt.name, tt.valid, err)
+		}
+	}
+}
+
+func TestInitiateRecurringPaymentRollsDates(t *testing.T) {
+	var sent []OpenFinanceRecurrence
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request OpenFinanceRecurringPaymentRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		sent = append(sent, request.Payment.Recurrence)
+		json.NewEncoder(w).Encode(OpenFinancePaymentResponse{PaymentID: "urn:efi:rec"})
+	}))
+	client.BusinessDays = calendar.NewScheduler(calendar.New(), calendar.RollFollowing)
+
+	if _, err := client.OpenFinance().InitiateRecurringPayment(nil); err == nil {
+		t.Fatal("expected an error for a nil request")
+	}
+
+	request := func(recurrence OpenFinanceRecurrence) *OpenFinanceRecurringPaymentRequest {
+		return &OpenFinanceRecurringPaymentRequest{Payment: OpenFinanceRecurringPaymentInfo{Value: "10.00", Recurrence: recurrence}}
+	}
+
+	if _, err := client.OpenFinance().InitiateRecurringPayment(request(OpenFinanceRecurrence{Type: RecurrenceMonthly, StartDate: "2030-01-12", Quantity: 2, DayOfMonth: 12})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.OpenFinance().InitiateRecurringPayment(request(OpenFinanceRecurrence{Type: RecurrenceWeekly, StartDate: "2030-01-12", Quantity: 2, DayOfWeek: WeekdaySaturday})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.OpenFinance().InitiateRecurringPayment(request(OpenFinanceRecurrence{Type: RecurrenceCustom, Dates: []string{"2030-01-12", "2030-01-15"}})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sent) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(sent))
+	}
+	if sent[0].StartDate != "2030-01-14" {
+		t.Errorf("expected the monthly start to roll to Monday, got %s", sent[0].StartDate)
+	}
+	if sent[1].StartDate != "2030-01-12" {
+		t.Errorf("expected the weekly start to keep its weekday, got %s", sent[1].StartDate)
+	}
+	if strings.Join(sent[2].Dates, ",") != "2030-01-14,2030-01-15" {
+		t.Errorf("unexpected custom dates %v", sent[2].Dates)
+	}
+
+	_, err := client.OpenFinance().InitiateRecurringPayment(request(OpenFinanceRecurrence{Type: RecurrenceCustom, Dates: []string{"2030-01-12", "2030-01-13"}}))
+	if err == nil || len(sent) != 3 {
+		t.Fatalf("expected colliding dates to be rejected before sending, got %v", err)
+	}
+}
+
+func TestCancelRecurring(t *testing.T) {
+	var paths, bodies []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		paths = append(paths, r.Method+" "+r.URL.EscapedPath())
+		bodies = append(bodies, string(body))
+		if strings.Contains(r.URL.Path, "missing") {
+			http.Error(w, `{"nome":"pagamento_nao_encontrado"}`, http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(OpenFinanceRecurringCancellationResponse{PaymentID: "urn:efi:rec", Status: PaymentStatusRejected})
+	}))
+
+	if _, err := client.OpenFinance().CancelRecurringPayment("urn:efi:rec"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.OpenFinance().CancelRecurringOccurrence("urn:efi:rec", "E1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.OpenFinance().CancelRecurringOccurrence("urn:efi:rec", ""); err == nil {
+		t.Fatal("expected an error without an endToEndId")
+	}
+	_, err := client.OpenFinance().CancelRecurringPayment("missing")
+	if err == nil || !strings.Contains(err.Error(), "failed to cancel recurring payment with status 404") {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if len(paths) != 3 || paths[0] != "PATCH /v1/pagamentos-recorrentes/pix/urn:efi:rec/cancelar" {
+		t.Fatalf("unexpected requests %v", paths)
+	}
+	if bodies[0] != "{}" || bodies[1] != `{"endToEndId":"E1"}` {
+		t.Fatalf("unexpected bodies %v", bodies)
+	}
+}
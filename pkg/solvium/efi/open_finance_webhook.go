@@ -0,0 +1,269 @@
+package efi
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	DefaultOpenFinanceSignatureHeader = "X-Efi-Signature"
+	DefaultOpenFinanceMaxBodyBytes    = 1 << 20
+)
+
+var (
+	ErrWebhookSignature   = errors.New("invalid webhook HMAC signature")
+	ErrWebhookCertificate = errors.New("invalid webhook client certificate")
+)
+
+type OpenFinanceWebhookHandler struct {
+	Security           OpenFinanceWebhookSecurity
+	SignatureHeader    string
+	ClientCAs          *x509.CertPool
+	PinnedCertificates []string
+	AllowedCommonNames []string
+	MaxBodyBytes       int64
+
+	mu       sync.RWMutex
+	payments []func(OpenFinancePaymentEvent) error
+	refunds  []func(OpenFinanceRefundEvent) error
+}
+
+func NewOpenFinanceWebhookHandler(security OpenFinanceWebhookSecurity) *OpenFinanceWebhookHandler {
+	return &OpenFinanceWebhookHandler{
+		Security:        security,
+		SignatureHeader: DefaultOpenFinanceSignatureHeader,
+		MaxBodyBytes:    DefaultOpenFinanceMaxBodyBytes,
+	}
+}
+
+func (o *OpenFinance) WebhookHandler(config *OpenFinanceConfig) *OpenFinanceWebhookHandler {
+	security := OpenFinanceWebhookSecurity{Type: WebhookSecurityTypeMTLS}
+	if config != nil && config.WebhookSecurity.Type != "" {
+		security = config.WebhookSecurity
+	}
+	return NewOpenFinanceWebhookHandler(security)
+}
+
+func (h *OpenFinanceWebhookHandler) OnPayment(callback func(OpenFinancePaymentEvent) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.payments = append(h.payments, callback)
+}
+
+func (h *OpenFinanceWebhookHandler) OnRefund(callback func(OpenFinanceRefundEvent) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.refunds = append(h.refunds, callback)
+}
+
+func (h *OpenFinanceWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	maxBodyBytes := h.MaxBodyBytes
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = DefaultOpenFinanceMaxBodyBytes
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Verify(r, body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	events, err := ParseOpenFinanceWebhook(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Dispatch(events); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *OpenFinanceWebhookHandler) Verify(r *http.Request, body []byte) error {
+	switch h.Security.Type {
+	case WebhookSecurityTypeHMAC:
+		return h.verifyHMAC(r, body)
+	case WebhookSecurityTypeMTLS:
+		return h.verifyMTLS(r)
+	}
+	return fmt.Errorf("unsupported webhook security type %q", h.Security.Type)
+}
+
+func (h *OpenFinanceWebhookHandler) Dispatch(events *OpenFinanceWebhookEvents) error {
+	h.mu.RLock()
+	payments := append([]func(OpenFinancePaymentEvent) error(nil), h.payments...)
+	refunds := append([]func(OpenFinanceRefundEvent) error(nil), h.refunds...)
+	h.mu.RUnlock()
+
+	for _, event := range events.Payments {
+		for _, callback := range payments {
+			if err := callback(event); err != nil {
+				return fmt.Errorf("payment callback failed for %s: %w", event.PaymentID, err)
+			}
+		}
+	}
+
+	for _, event := range events.Refunds {
+		for _, callback := range refunds {
+			if err := callback(event); err != nil {
+				return fmt.Errorf("refund callback failed for %s: %w", event.PaymentID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *OpenFinanceWebhookHandler) verifyHMAC(r *http.Request, body []byte) error {
+	if h.Security.Key == "" {
+		return fmt.Errorf("%w: no HMAC key configured", ErrWebhookSignature)
+	}
+
+	header := h.SignatureHeader
+	if header == "" {
+		header = DefaultOpenFinanceSignatureHeader
+	}
+
+	signature := strings.TrimSpace(r.Header.Get(header))
+	if signature == "" {
+		signature = r.URL.Query().Get("hmac")
+	}
+	signature = strings.TrimPrefix(signature, "sha256=")
+	if signature == "" {
+		return fmt.Errorf("%w: missing signature", ErrWebhookSignature)
+	}
+
+	mac := hmac.New(sha256.New, []byte(h.Security.Key))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	var received []byte
+	if decoded, err := hex.DecodeString(signature); err == nil {
+		received = decoded
+	} else if decoded, err := base64.StdEncoding.DecodeString(signature); err == nil {
+		received = decoded
+	} else {
+		return fmt.Errorf("%w: malformed signature", ErrWebhookSignature)
+	}
+
+	if !hmac.Equal(expected, received) {
+		return ErrWebhookSignature
+	}
+
+	return nil
+}
+
+func (h *OpenFinanceWebhookHandler) verifyMTLS(r *http.Request) error {
+	if h.ClientCAs == nil && len(h.PinnedCertificates) == 0 {
+		return fmt.Errorf("%w: no client CA pool or pinned certificate configured", ErrWebhookCertificate)
+	}
+
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return fmt.Errorf("%w: no client certificate presented", ErrWebhookCertificate)
+	}
+
+	leaf := r.TLS.PeerCertificates[0]
+
+	if h.ClientCAs != nil {
+		intermediates := x509.NewCertPool()
+		for _, cert := range r.TLS.PeerCertificates[1:] {
+			intermediates.AddCert(cert)
+		}
+
+		_, err := leaf.Verify(x509.VerifyOptions{
+			Roots:         h.ClientCAs,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrWebhookCertificate, err)
+		}
+	}
+
+	if len(h.PinnedCertificates) > 0 {
+		fingerprint := sha256.Sum256(leaf.Raw)
+		pinned := false
+		for _, pin := range h.PinnedCertificates {
+			if strings.EqualFold(strings.ReplaceAll(pin, ":", ""), hex.EncodeToString(fingerprint[:])) {
+				pinned = true
+				break
+			}
+		}
+		if !pinned {
+			return fmt.Errorf("%w: certificate is not pinned", ErrWebhookCertificate)
+		}
+	}
+
+	if len(h.AllowedCommonNames) > 0 {
+		for _, name := range h.AllowedCommonNames {
+			if leaf.Subject.CommonName == name {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: unexpected common name %q", ErrWebhookCertificate, leaf.Subject.CommonName)
+	}
+
+	return nil
+}
+
+func ParseOpenFinanceWebhook(payload []byte) (*OpenFinanceWebhookEvents, error) {
+	var raw []json.RawMessage
+
+	trimmed := bytes.TrimSpace(payload)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse webhook callback: %w", err)
+		}
+	} else {
+		raw = []json.RawMessage{trimmed}
+	}
+
+	events := &OpenFinanceWebhookEvents{}
+	for _, item := range raw {
+		var refund OpenFinanceRefundEvent
+		if err := json.Unmarshal(item, &refund); err != nil {
+			return nil, fmt.Errorf("failed to parse webhook callback: %w", err)
+		}
+
+		if refund.PaymentID == "" {
+			return nil, fmt.Errorf("webhook callback is missing identificadorPagamento")
+		}
+
+		if refund.Type == OpenFinanceWebhookEventRefund || (refund.Type == "" && refund.RefundID != "") {
+			events.Refunds = append(events.Refunds, refund)
+			continue
+		}
+
+		var payment OpenFinancePaymentEvent
+		if err := json.Unmarshal(item, &payment); err != nil {
+			return nil, fmt.Errorf("failed to parse webhook callback: %w", err)
+		}
+		events.Payments = append(events.Payments, payment)
+	}
+
+	return events, nil
+}
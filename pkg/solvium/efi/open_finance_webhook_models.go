@@ -0,0 +1,36 @@
+package efi
+
+type OpenFinanceWebhookEventType string
+
+const (
+	OpenFinanceWebhookEventPayment OpenFinanceWebhookEventType = "pagamento"
+	OpenFinanceWebhookEventRefund  OpenFinanceWebhookEventType = "devolucao"
+)
+
+type OpenFinancePaymentEvent struct {
+	Type       OpenFinanceWebhookEventType `json:"tipo,omitempty"`
+	PaymentID  string                      `json:"identificadorPagamento"`
+	EndToEndID string                      `json:"endToEndId,omitempty"`
+	OwnID      string                      `json:"idProprio,omitempty"`
+	Value      string                      `json:"valor,omitempty"`
+	Status     OpenFinancePaymentStatus    `json:"status"`
+	CreatedAt  string                      `json:"dataCriacao,omitempty"`
+	Error      string                      `json:"erro,omitempty"`
+}
+
+type OpenFinanceRefundEvent struct {
+	Type       OpenFinanceWebhookEventType `json:"tipo,omitempty"`
+	PaymentID  string                      `json:"identificadorPagamento"`
+	RefundID   string                      `json:"identificadorDevolucao,omitempty"`
+	EndToEndID string                      `json:"endToEndId,omitempty"`
+	OwnID      string                      `json:"idProprio,omitempty"`
+	Value      string                      `json:"valor,omitempty"`
+	Status     OpenFinancePaymentStatus    `json:"status"`
+	CreatedAt  string                      `json:"dataCriacao,omitempty"`
+	Error      string                      `json:"erro,omitempty"`
+}
+
+type OpenFinanceWebhookEvents struct {
+	Payments []OpenFinancePaymentEvent
+	Refunds  []OpenFinanceRefundEvent
+}
@@ -0,0 +1,178 @@
+package efi
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOpenFinanceWebhookHandlerHMAC(t *testing.T) {
+	body := `[{"identificadorPagamento":"urn:abc:1","idProprio":"order-1","status":"aceito"},{"tipo":"devolucao","identificadorPagamento":"urn:abc:1","identificadorDevolucao":"dev-1","status":"devolvido"}]`
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(body))
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	handler := NewOpenFinanceWebhookHandler(OpenFinanceWebhookSecurity{Type: WebhookSecurityTypeHMAC, Key: "secret"})
+
+	var payments, refunds int
+	handler.OnPayment(func(event OpenFinancePaymentEvent) error {
+		payments++
+		if event.OwnID != "order-1" {
+			t.Errorf("unexpected idProprio %q", event.OwnID)
+		}
+		return nil
+	})
+	handler.OnRefund(func(event OpenFinanceRefundEvent) error {
+		refunds++
+		if event.RefundID != "dev-1" {
+			t.Errorf("unexpected refund id %q", event.RefundID)
+		}
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set(DefaultOpenFinanceSignatureHeader, signature)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if payments != 1 || refunds != 1 {
+		t.Fatalf("expected 1 payment and 1 refund, got %d and %d", payments, refunds)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/webhook?hmac=deadbeef", strings.NewReader(body))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for bad signature, got %d", rec.Code)
+	}
+}
+
+func TestOpenFinanceWebhookHandlerMTLSRequiresCertificate(t *testing.T) {
+	handler := NewOpenFinanceWebhookHandler(OpenFinanceWebhookSecurity{Type: WebhookSecurityTypeMTLS})
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(`{"identificadorPagamento":"urn:abc:1","status":"aceito"}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without client certificate, got %d", rec.Code)
+	}
+}
+
+func TestOpenFinanceWebhookHandlerMTLSVerifiesCertificate(t *testing.T) {
+	ca, caKey := newTestCertificate(t, "efi-ca", nil, nil)
+	trusted, _ := newTestCertificate(t, "efi-webhook", ca, caKey)
+	selfSigned, _ := newTestCertificate(t, "efi-webhook", nil, nil)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	fingerprint := sha256.Sum256(selfSigned.Raw)
+
+	tests := []struct {
+		name    string
+		handler func(*OpenFinanceWebhookHandler)
+		cert    *x509.Certificate
+		code    int
+	}{
+		{"unconfigured", func(h *OpenFinanceWebhookHandler) { h.AllowedCommonNames = []string{"efi-webhook"} }, trusted, http.StatusUnauthorized},
+		{"self-signed", func(h *OpenFinanceWebhookHandler) { h.ClientCAs = roots }, selfSigned, http.StatusUnauthorized},
+		{"trusted", func(h *OpenFinanceWebhookHandler) { h.ClientCAs = roots }, trusted, http.StatusOK},
+		{"unexpected common name", func(h *OpenFinanceWebhookHandler) {
+			h.ClientCAs = roots
+			h.AllowedCommonNames = []string{"other"}
+		}, trusted, http.StatusUnauthorized},
+		{"pinned", func(h *OpenFinanceWebhookHandler) {
+			h.PinnedCertificates = []string{hex.EncodeToString(fingerprint[:])}
+		}, selfSigned, http.StatusOK},
+		{"not pinned", func(h *OpenFinanceWebhookHandler) {
+			h.PinnedCertificates = []string{hex.EncodeToString(fingerprint[:])}
+		}, trusted, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		handler := NewOpenFinanceWebhookHandler(OpenFinanceWebhookSecurity{Type: WebhookSecurityTypeMTLS})
+		tt.handler(handler)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(`{"identificadorPagamento":"urn:abc:1","status":"aceito"}`))
+		req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{tt.cert}}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != tt.code {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.code, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func newTestCertificate(t *testing.T, commonName string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+	}
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	return cert, key
+}
+
+func TestOpenFinanceWebhookHandlerDefaults(t *testing.T) {
+	client := newTestClient(t, http.NotFoundHandler())
+	if handler := client.OpenFinance().WebhookHandler(nil); handler.Security.Type != WebhookSecurityTypeMTLS {
+		t.Fatalf("expected a nil config to default to mTLS, got %q", handler.Security.Type)
+	}
+
+	body := `{"identificadorPagamento":"urn:abc:1","status":"aceito"}`
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(body))
+
+	handler := &OpenFinanceWebhookHandler{Security: OpenFinanceWebhookSecurity{Type: WebhookSecurityTypeHMAC, Key: "secret"}}
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set(DefaultOpenFinanceSignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected a struct literal handler to read the body, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
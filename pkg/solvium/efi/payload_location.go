@@ -2,6 +2,7 @@ package efi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -83,6 +84,25 @@ func (p *PayloadLocation) List(startDate, endDate time.Time, options *ListPayloa
 	return &listResp, nil
 }
 
+func (p *PayloadLocation) Iterate(startDate, endDate time.Time, options *ListPayloadLocationsOptions, pageOptions *PageOptions) *Iterator[PayloadLocationResponse] {
+	return newPageIterator(pageOptions, func(ctx context.Context, page, pageSize int) ([]PayloadLocationResponse, int, error) {
+		pageFilter := ListPayloadLocationsOptions{}
+		if options != nil {
+			pageFilter = *options
+		}
+		pageFilter.PaginaAtual = page
+		if pageSize > 0 {
+			pageFilter.ItensPorPagina = pageSize
+		}
+
+		list, err := p.List(startDate, endDate, &pageFilter)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Loc, list.Parametros.Paginacao.QuantidadeDePaginas, nil
+	})
+}
+
 
 func (p *PayloadLocation) GetByID(id int64) (*PayloadLocationResponse, error) {
 	resp, err := p.client.Request("GET", fmt.Sprintf("/v2/loc/%d", id), nil)
@@ -0,0 +1,232 @@
+package efi
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type PayloadLocationPool struct {
+	locations *PayloadLocation
+
+	Size int
+
+	mu        sync.Mutex
+	available map[PayloadLocationType][]PayloadLocationResponse
+	filling   map[PayloadLocationType]int
+	leased    map[int64]PayloadLocationType
+}
+
+func NewPayloadLocationPool(locations *PayloadLocation, size int) *PayloadLocationPool {
+	return &PayloadLocationPool{
+		locations: locations,
+		Size:      size,
+		available: make(map[PayloadLocationType][]PayloadLocationResponse),
+		filling:   make(map[PayloadLocationType]int),
+		leased:    make(map[int64]PayloadLocationType),
+	}
+}
+
+func (c *Client) PayloadLocationPool(size int) *PayloadLocationPool {
+	return NewPayloadLocationPool(c.PayloadLocation(), size)
+}
+
+func (p *PayloadLocationPool) Fill(tipoCob PayloadLocationType) error {
+	for {
+		p.mu.Lock()
+		if len(p.available[tipoCob])+p.filling[tipoCob] >= p.Size {
+			p.mu.Unlock()
+			return nil
+		}
+		p.filling[tipoCob]++
+		p.mu.Unlock()
+
+		location, err := p.locations.Create(CreatePayloadLocationRequest{TipoCob: tipoCob})
+
+		p.mu.Lock()
+		p.filling[tipoCob]--
+		p.mu.Unlock()
+
+		if err != nil {
+			return fmt.Errorf("failed to fill %s location pool: %w", tipoCob, err)
+		}
+
+		p.put(*location)
+	}
+}
+
+func (p *PayloadLocationPool) LoadUnlinked(startDate, endDate time.Time) (int, error) {
+	loaded := 0
+	options := &ListPayloadLocationsOptions{ItensPorPagina: 100}
+
+	for {
+		page, err := p.locations.List(startDate, endDate, options)
+		if err != nil {
+			return loaded, err
+		}
+
+		for _, location := range page.Loc {
+			if location.TxID != "" || location.TipoCob == "" {
+				continue
+			}
+			if p.put(location) {
+				loaded++
+			}
+		}
+
+		if options.PaginaAtual+1 >= page.Parametros.Paginacao.QuantidadeDePaginas {
+			return loaded, nil
+		}
+		options.PaginaAtual++
+	}
+}
+
+func (p *PayloadLocationPool) Acquire(tipoCob PayloadLocationType) (PayloadLocationResponse, error) {
+	p.mu.Lock()
+	queue := p.available[tipoCob]
+	if len(queue) > 0 {
+		location := queue[0]
+		p.available[tipoCob] = queue[1:]
+		p.leased[location.ID] = tipoCob
+		p.mu.Unlock()
+		return location, nil
+	}
+	p.mu.Unlock()
+
+	location, err := p.locations.Create(CreatePayloadLocationRequest{TipoCob: tipoCob})
+	if err != nil {
+		return PayloadLocationResponse{}, fmt.Errorf("failed to acquire %s location: %w", tipoCob, err)
+	}
+
+	p.mu.Lock()
+	p.leased[location.ID] = tipoCob
+	p.mu.Unlock()
+
+	return *location, nil
+}
+
+func (p *PayloadLocationPool) Release(location PayloadLocationResponse) {
+	p.mu.Lock()
+	delete(p.leased, location.ID)
+	p.mu.Unlock()
+
+	p.put(location)
+}
+
+func (p *PayloadLocationPool) Complete(location PayloadLocationResponse) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.leased, location.ID)
+}
+
+func (p *PayloadLocationPool) Discard(location PayloadLocationResponse) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.leased, location.ID)
+}
+
+func (p *PayloadLocationPool) Recycle(id int64) error {
+	location, err := p.locations.UnlinkTxID(id)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	if location.TipoCob == "" {
+		location.TipoCob = p.leased[id]
+	}
+	delete(p.leased, id)
+	p.mu.Unlock()
+
+	if location.TipoCob == "" {
+		return fmt.Errorf("location %d has no tipoCob and cannot be pooled", id)
+	}
+
+	location.TxID = ""
+	p.put(*location)
+	return nil
+}
+
+func (p *PayloadLocationPool) Available(tipoCob PayloadLocationType) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.available[tipoCob])
+}
+
+func (p *PayloadLocationPool) CreateImmediateCharge(txid string, req CreateImmediateChargeRequest) (*ImmediateChargeResponse, error) {
+	if err := req.Valor.Validate(); err != nil {
+		return nil, err
+	}
+
+	location, err := p.Acquire(PayloadLocationTypeCOB)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Loc = &LocInfo{ID: int(location.ID)}
+
+	var charge *ImmediateChargeResponse
+	if txid == "" {
+		charge, err = p.locations.client.ImmediateCharge().CreateWithoutTxid(req)
+	} else {
+		charge, err = p.locations.client.ImmediateCharge().CreateWithTxid(txid, req)
+	}
+	if err != nil {
+		p.Discard(location)
+		return nil, err
+	}
+
+	p.Complete(location)
+	return charge, nil
+}
+
+func (p *PayloadLocationPool) CreateDueCharge(txid string, req CreateDueChargeRequest) (*DueChargeResponse, error) {
+	dueDate, err := p.locations.client.adjustScheduledDate(req.Calendario.DataDeVencimento)
+	if err != nil {
+		return nil, fmt.Errorf("failed to adjust due date: %w", err)
+	}
+	req.Calendario.DataDeVencimento = dueDate
+
+	location, err := p.Acquire(PayloadLocationTypeCOBV)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Loc = &LocInfo{ID: int(location.ID)}
+
+	charge, err := p.locations.client.DueCharge().Create(txid, req)
+	if err != nil {
+		p.Discard(location)
+		return nil, err
+	}
+
+	p.Complete(location)
+	return charge, nil
+}
+
+// AssignToReview leases a location for req. Once ReviewCharge succeeds, call
+// Complete; if it fails, Release the location when Efi rejected the review and
+// Discard it when the outcome is unknown.
+func (p *PayloadLocationPool) AssignToReview(req *ReviewChargeRequest) (PayloadLocationResponse, error) {
+	location, err := p.Acquire(PayloadLocationTypeCOB)
+	if err != nil {
+		return PayloadLocationResponse{}, err
+	}
+
+	req.Loc = LocInfo{ID: int(location.ID)}
+	return location, nil
+}
+
+func (p *PayloadLocationPool) put(location PayloadLocationResponse) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, existing := range p.available[location.TipoCob] {
+		if existing.ID == location.ID {
+			return false
+		}
+	}
+
+	p.available[location.TipoCob] = append(p.available[location.TipoCob], location)
+	return true
+}
@@ -0,0 +1,105 @@
+package efi
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPayloadLocationPoolFillConcurrently(t *testing.T) {
+	var created int64
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := atomic.AddInt64(&created, 1)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(PayloadLocationResponse{ID: id, TipoCob: PayloadLocationTypeCOB})
+	}))
+
+	pool := client.PayloadLocationPool(5)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := pool.Fill(PayloadLocationTypeCOB); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if created != 5 || pool.Available(PayloadLocationTypeCOB) != 5 {
+		t.Fatalf("expected 5 locations, created %d with %d available", created, pool.Available(PayloadLocationTypeCOB))
+	}
+}
+
+func TestPayloadLocationPoolDiscardsLocationAfterFailedCreate(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/loc":
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(PayloadLocationResponse{ID: 7, TipoCob: PayloadLocationTypeCOB})
+		default:
+			w.WriteHeader(http.StatusGatewayTimeout)
+		}
+	}))
+
+	pool := client.PayloadLocationPool(1)
+	if err := pool.Fill(PayloadLocationTypeCOB); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := pool.CreateImmediateCharge("txid", CreateImmediateChargeRequest{}); err == nil {
+		t.Fatal("expected create to fail")
+	}
+
+	if available := pool.Available(PayloadLocationTypeCOB); available != 0 {
+		t.Fatalf("expected the location to be discarded, got %d available", available)
+	}
+	if len(pool.leased) != 0 {
+		t.Fatalf("expected no leased locations, got %v", pool.leased)
+	}
+}
+
+func TestPayloadLocationPoolKeepsLocationOnInvalidRequest(t *testing.T) {
+	var charges int64
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/loc" {
+			atomic.AddInt64(&charges, 1)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("{}"))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(PayloadLocationResponse{ID: 9, TipoCob: PayloadLocationTypeCOB})
+	}))
+
+	pool := client.PayloadLocationPool(1)
+	if err := pool.Fill(PayloadLocationTypeCOB); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	invalid := CreateImmediateChargeRequest{Valor: Valor{Original: "1.00", Retirada: &Retirada{}}}
+	if _, err := pool.CreateImmediateCharge("txid", invalid); err == nil {
+		t.Fatal("expected validation to fail")
+	}
+	if available := pool.Available(PayloadLocationTypeCOB); available != 1 || charges != 0 {
+		t.Fatalf("expected the location to stay pooled without a request, got %d available and %d requests", available, charges)
+	}
+
+	var review ReviewChargeRequest
+	location, err := pool.AssignToReview(&review)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if review.Loc.ID != int(location.ID) || len(pool.leased) != 1 {
+		t.Fatalf("expected location %d to be leased for review, got %+v", location.ID, review.Loc)
+	}
+
+	pool.Complete(location)
+	if len(pool.leased) != 0 || pool.Available(PayloadLocationTypeCOB) != 0 {
+		t.Fatalf("expected the completed review to drop the lease, got %v", pool.leased)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"time"
 )
 
 
@@ -99,6 +101,68 @@ func (p *PaymentSplit) GetConfig(id string, revision int) (*PaymentSplitConfigRe
 }
 
 
+func (p *PaymentSplit) GetConfigRevision(id string, revision int) (*PaymentSplitConfigResponse, error) {
+	resp, err := p.client.Request("GET", fmt.Sprintf("/v2/gn/split/config/%s?revisao=%d", id, revision), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment split config revision: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get payment split config revision with status %d: %s", resp.StatusCode, body)
+	}
+
+	var configResp PaymentSplitConfigResponse
+	if err := json.NewDecoder(resp.Body).Decode(&configResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &configResp, nil
+}
+
+
+func (p *PaymentSplit) ListConfigs(startDate, endDate time.Time, options *ListPaymentSplitConfigsOptions) (*PaymentSplitConfigListResponse, error) {
+	query := url.Values{}
+	query.Add("inicio", startDate.Format(time.RFC3339))
+	query.Add("fim", endDate.Format(time.RFC3339))
+
+	if options != nil {
+		if options.Status != "" {
+			query.Add("status", options.Status)
+		}
+		if options.Descricao != "" {
+			query.Add("descricao", options.Descricao)
+		}
+		if options.PaginaAtual > 0 {
+			query.Add("paginacao.paginaAtual", fmt.Sprintf("%d", options.PaginaAtual))
+		}
+		if options.ItensPorPagina > 0 {
+			query.Add("paginacao.itensPorPagina", fmt.Sprintf("%d", options.ItensPorPagina))
+		}
+	}
+
+	path := fmt.Sprintf("/v2/gn/split/config?%s", query.Encode())
+	resp, err := p.client.Request("GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payment split configs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to list payment split configs with status %d: %s", resp.StatusCode, body)
+	}
+
+	var listResp PaymentSplitConfigListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&listResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &listResp, nil
+}
+
+
 func (p *PaymentSplit) LinkImmediateCharge(txid, splitConfigID string) error {
 	resp, err := p.client.Request("PUT", fmt.Sprintf("/v2/gn/split/cob/%s/vinculo/%s", txid, splitConfigID), nil)
 	if err != nil {
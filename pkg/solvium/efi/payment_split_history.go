@@ -0,0 +1,103 @@
+package efi
+
+import (
+	"fmt"
+	"sort"
+)
+
+func (p *PaymentSplit) ConfigHistory(id string) (*SplitConfigHistory, error) {
+	latest, err := p.GetConfig(id, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &SplitConfigHistory{ID: id}
+	for revision := 0; revision < latest.Revisao; revision++ {
+		config, err := p.GetConfigRevision(id, revision)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get revision %d: %w", revision, err)
+		}
+		history.Revisions = append(history.Revisions, *config)
+	}
+	history.Revisions = append(history.Revisions, *latest)
+
+	for i := 1; i < len(history.Revisions); i++ {
+		history.Diffs = append(history.Diffs, DiffSplitConfigs(history.Revisions[i-1], history.Revisions[i]))
+	}
+
+	return history, nil
+}
+
+func DiffSplitConfigs(from, to PaymentSplitConfigResponse) SplitConfigDiff {
+	diff := SplitConfigDiff{
+		FromRevisao: from.Revisao,
+		ToRevisao:   to.Revisao,
+	}
+
+	diff.compare("descricao", from.Descricao, to.Descricao)
+	diff.compare("status", from.Status, to.Status)
+	diff.compare("lancamento.imediato", fmt.Sprintf("%t", from.Lancamento.Imediato), fmt.Sprintf("%t", to.Lancamento.Imediato))
+	diff.compare("split.divisaoTarifa", from.Split.DivisaoTarifa, to.Split.DivisaoTarifa)
+	diff.compare("split.minhaParte.tipo", from.Split.MinhaParte.Tipo, to.Split.MinhaParte.Tipo)
+	diff.compare("split.minhaParte.valor", from.Split.MinhaParte.Valor, to.Split.MinhaParte.Valor)
+
+	fromRepasses := indexRepasses(from.Split.Repasses)
+	toRepasses := indexRepasses(to.Split.Repasses)
+
+	keys := make([]string, 0, len(fromRepasses)+len(toRepasses))
+	for key := range fromRepasses {
+		keys = append(keys, key)
+	}
+	for key := range toRepasses {
+		if _, ok := fromRepasses[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		field := fmt.Sprintf("split.repasses[%s]", key)
+		before, hadBefore := fromRepasses[key]
+		after, hasAfter := toRepasses[key]
+
+		switch {
+		case !hadBefore:
+			diff.Changes = append(diff.Changes, SplitConfigChange{Kind: SplitConfigChangeAdded, Field: field, To: describeRepasse(after)})
+		case !hasAfter:
+			diff.Changes = append(diff.Changes, SplitConfigChange{Kind: SplitConfigChangeRemoved, Field: field, From: describeRepasse(before)})
+		default:
+			diff.compare(field+".tipo", before.Tipo, after.Tipo)
+			diff.compare(field+".valor", before.Valor, after.Valor)
+		}
+	}
+
+	return diff
+}
+
+func (d *SplitConfigDiff) compare(field, from, to string) {
+	if from == to {
+		return
+	}
+	d.Changes = append(d.Changes, SplitConfigChange{
+		Kind:  SplitConfigChangeModified,
+		Field: field,
+		From:  from,
+		To:    to,
+	})
+}
+
+func indexRepasses(repasses []SplitRepasse) map[string]SplitRepasse {
+	index := make(map[string]SplitRepasse, len(repasses))
+	for _, repasse := range repasses {
+		document := repasse.Favorecido.CPF
+		if document == "" {
+			document = repasse.Favorecido.CNPJ
+		}
+		index[fmt.Sprintf("%s/%s", repasse.Favorecido.Conta, document)] = repasse
+	}
+	return index
+}
+
+func describeRepasse(repasse SplitRepasse) string {
+	return fmt.Sprintf("%s %s", repasse.Tipo, repasse.Valor)
+}
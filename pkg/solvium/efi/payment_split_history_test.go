@@ -0,0 +1,103 @@
+package efi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestConfigHistoryFetchesEveryRevision(t *testing.T) {
+	revisions := []PaymentSplitConfigResponse{
+		{ID: "cfg", Revisao: 0, Descricao: "inicial", Split: SplitConfig{MinhaParte: SplitMinhaParte{Tipo: "porcentagem", Valor: "60.00"}}},
+		{ID: "cfg", Revisao: 1, Descricao: "ajustada", Split: SplitConfig{MinhaParte: SplitMinhaParte{Tipo: "porcentagem", Valor: "50.00"}}},
+	}
+
+	var requested []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Query().Get("revisao"))
+		revision := len(revisions) - 1
+		switch r.URL.Query().Get("revisao") {
+		case "0":
+			revision = 0
+		case "1":
+			revision = 1
+		}
+		json.NewEncoder(w).Encode(revisions[revision])
+	}))
+
+	history, err := client.PaymentSplit().ConfigHistory("cfg")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(requested) != 2 || requested[0] != "" || requested[1] != "0" {
+		t.Fatalf("expected latest then revisao=0, got %q", requested)
+	}
+	if len(history.Revisions) != 2 || history.Revisions[0].Descricao != "inicial" {
+		t.Fatalf("unexpected revisions: %+v", history.Revisions)
+	}
+	if len(history.Diffs) != 1 || len(history.Diffs[0].Changes) != 2 {
+		t.Fatalf("unexpected diffs: %+v", history.Diffs)
+	}
+}
+
+func TestDiffSplitConfigs(t *testing.T) {
+	from := PaymentSplitConfigResponse{
+		Revisao: 0,
+		Status:  "ATIVA",
+		Split: SplitConfig{
+			Repasses: []SplitRepasse{
+				{Tipo: "porcentagem", Valor: "20.00", Favorecido: SplitFavorecido{Conta: "111", CPF: "12345678909"}},
+				{Tipo: "porcentagem", Valor: "10.00", Favorecido: SplitFavorecido{Conta: "222", CNPJ: "12345678000195"}},
+			},
+		},
+	}
+	to := PaymentSplitConfigResponse{
+		Revisao: 1,
+		Status:  "ATIVA",
+		Split: SplitConfig{
+			Repasses: []SplitRepasse{
+				{Tipo: "porcentagem", Valor: "25.00", Favorecido: SplitFavorecido{Conta: "111", CPF: "12345678909"}},
+				{Tipo: "fixo", Valor: "5.00", Favorecido: SplitFavorecido{Conta: "333", CPF: "98765432100"}},
+			},
+		},
+	}
+
+	diff := DiffSplitConfigs(from, to)
+	if diff.FromRevisao != 0 || diff.ToRevisao != 1 {
+		t.Fatalf("unexpected revisions %d -> %d", diff.FromRevisao, diff.ToRevisao)
+	}
+
+	expected := []SplitConfigChange{
+		{Kind: SplitConfigChangeModified, Field: "split.repasses[111/12345678909].valor", From: "20.00", To: "25.00"},
+		{Kind: SplitConfigChangeRemoved, Field: "split.repasses[222/12345678000195]", From: "porcentagem 10.00"},
+		{Kind: SplitConfigChangeAdded, Field: "split.repasses[333/98765432100]", To: "fixo 5.00"},
+	}
+	if len(diff.Changes) != len(expected) {
+		t.Fatalf("expected %d changes, got %+v", len(expected), diff.Changes)
+	}
+	for i, change := range expected {
+		if diff.Changes[i] != change {
+			t.Fatalf("change %d: expected %+v, got %+v", i, change, diff.Changes[i])
+		}
+	}
+}
+
+func TestGetConfigZeroRevisionMeansLatest(t *testing.T) {
+	var queries []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		json.NewEncoder(w).Encode(PaymentSplitConfigResponse{ID: "cfg"})
+	}))
+
+	if _, err := client.PaymentSplit().GetConfig("cfg", 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.PaymentSplit().GetConfigRevision("cfg", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(queries) != 2 || queries[0] != "" || queries[1] != "revisao=0" {
+		t.Fatalf("unexpected queries %q", queries)
+	}
+}
@@ -74,3 +74,42 @@ type SplitChargeResponse struct {
 	PixCopiaECola      string              `json:"pixCopiaECola,omitempty"`
 	Config             SplitConfigInfo     `json:"config,omitempty"`
 }
+
+type ListPaymentSplitConfigsOptions struct {
+	Status         string
+	Descricao      string
+	PaginaAtual    int
+	ItensPorPagina int
+}
+
+type PaymentSplitConfigListResponse struct {
+	Parametros Parametros                   `json:"parametros,omitempty"`
+	Configs    []PaymentSplitConfigResponse `json:"configs,omitempty"`
+}
+
+type SplitConfigChangeKind string
+
+const (
+	SplitConfigChangeAdded    SplitConfigChangeKind = "added"
+	SplitConfigChangeRemoved  SplitConfigChangeKind = "removed"
+	SplitConfigChangeModified SplitConfigChangeKind = "modified"
+)
+
+type SplitConfigChange struct {
+	Kind  SplitConfigChangeKind
+	Field string
+	From  string
+	To    string
+}
+
+type SplitConfigDiff struct {
+	FromRevisao int
+	ToRevisao   int
+	Changes     []SplitConfigChange
+}
+
+type SplitConfigHistory struct {
+	ID        string
+	Revisions []PaymentSplitConfigResponse
+	Diffs     []SplitConfigDiff
+}
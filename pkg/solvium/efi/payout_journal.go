@@ -0,0 +1,122 @@
+package efi
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+type PayoutState string
+
+const (
+	PayoutStatePending   PayoutState = "pending"
+	PayoutStateSent      PayoutState = "sent"
+	PayoutStateRejected  PayoutState = "rejected"
+	PayoutStateConfirmed PayoutState = "confirmed"
+)
+
+type PayoutJournalEntry struct {
+	Reference string      `json:"reference"`
+	IDEnvio   string      `json:"idEnvio"`
+	State     PayoutState `json:"state"`
+	Valor     string      `json:"valor,omitempty"`
+	E2EID     string      `json:"e2eId,omitempty"`
+	Status    string      `json:"status,omitempty"`
+	Error     string      `json:"error,omitempty"`
+	At        time.Time   `json:"at"`
+}
+
+type PayoutJournal interface {
+	Append(entry PayoutJournalEntry) error
+	Load() ([]PayoutJournalEntry, error)
+}
+
+type MemoryPayoutJournal struct {
+	mu      sync.Mutex
+	entries []PayoutJournalEntry
+}
+
+func NewMemoryPayoutJournal() *MemoryPayoutJournal {
+	return &MemoryPayoutJournal{}
+}
+
+func (j *MemoryPayoutJournal) Append(entry PayoutJournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.entries = append(j.entries, entry)
+	return nil
+}
+
+func (j *MemoryPayoutJournal) Load() ([]PayoutJournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return append([]PayoutJournalEntry(nil), j.entries...), nil
+}
+
+type FilePayoutJournal struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+func OpenFilePayoutJournal(path string) (*FilePayoutJournal, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open payout journal: %w", err)
+	}
+
+	return &FilePayoutJournal{path: path, file: file}, nil
+}
+
+func (j *FilePayoutJournal) Append(entry PayoutJournalEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payout journal entry: %w", err)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, err := j.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write payout journal: %w", err)
+	}
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync payout journal: %w", err)
+	}
+	return nil
+}
+
+func (j *FilePayoutJournal) Load() ([]PayoutJournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	file, err := os.Open(j.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payout journal: %w", err)
+	}
+	defer file.Close()
+
+	var entries []PayoutJournalEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry PayoutJournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read payout journal: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (j *FilePayoutJournal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.file.Close()
+}
@@ -0,0 +1,39 @@
+package efi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFilePayoutJournalIgnoresPartialLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "payouts.journal")
+
+	journal, err := OpenFilePayoutJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer journal.Close()
+
+	if err := journal.Append(PayoutJournalEntry{Reference: "a", IDEnvio: "id-a", State: PayoutStatePending}); err != nil {
+		t.Fatal(err)
+	}
+	if err := journal.Append(PayoutJournalEntry{Reference: "a", IDEnvio: "id-a", State: PayoutStateSent}); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"reference":"b","idEnv`)
+	file.Close()
+
+	entries, err := journal.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[1].State != PayoutStateSent {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+}
@@ -0,0 +1,336 @@
+package efi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Payout struct {
+	Reference   string
+	Valor       string
+	Favorecido  Favorecido
+	InfoPagador string
+}
+
+type PayoutResult struct {
+	Reference string
+	IDEnvio   string
+	Valor     string
+	State     PayoutState
+	E2EID     string
+	Status    string
+	Error     string
+}
+
+type PayoutSummary struct {
+	Results        []PayoutResult
+	Completed      int
+	Failed         int
+	Pending        int
+	Unknown        int
+	CompletedCents int64
+}
+
+type PayoutEngine struct {
+	client  *Client
+	journal PayoutJournal
+
+	Namespace          string
+	PayerKey           string
+	Concurrency        int
+	MaxRetries         int
+	ConfirmInterval    time.Duration
+	MaxConfirmAttempts int
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+func NewPayoutEngine(client *Client, journal PayoutJournal, namespace, payerKey string) *PayoutEngine {
+	return &PayoutEngine{
+		client:             client,
+		journal:            journal,
+		Namespace:          namespace,
+		PayerKey:           payerKey,
+		Concurrency:        4,
+		MaxRetries:         5,
+		ConfirmInterval:    3 * time.Second,
+		MaxConfirmAttempts: 20,
+	}
+}
+
+func (e *PayoutEngine) IDEnvio(reference string) string {
+	return TxIDForOrder(e.Namespace, reference)
+}
+
+func (e *PayoutEngine) Run(ctx context.Context, payouts []Payout) (*PayoutSummary, error) {
+	entries, err := e.journal.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	last := make(map[string]PayoutJournalEntry, len(entries))
+	for _, entry := range entries {
+		last[entry.Reference] = entry
+	}
+
+	results := make([]PayoutResult, len(payouts))
+	seen := make(map[string]bool, len(payouts))
+	for i, payout := range payouts {
+		if payout.Reference == "" {
+			return nil, fmt.Errorf("payout %d has no reference", i)
+		}
+		if seen[payout.Reference] {
+			return nil, fmt.Errorf("duplicate payout reference %q", payout.Reference)
+		}
+		seen[payout.Reference] = true
+
+		if _, err := ParseAmount(payout.Valor); err != nil {
+			return nil, fmt.Errorf("invalid amount for payout %q: %w", payout.Reference, err)
+		}
+
+		results[i] = PayoutResult{Reference: payout.Reference, IDEnvio: e.IDEnvio(payout.Reference), Valor: payout.Valor, State: PayoutStatePending}
+		if entry, ok := last[payout.Reference]; ok {
+			results[i].State = entry.State
+			results[i].E2EID = entry.E2EID
+			results[i].Status = entry.Status
+			results[i].Error = entry.Error
+		}
+	}
+
+	e.send(ctx, payouts, results)
+	e.confirm(ctx, results)
+
+	summary := &PayoutSummary{Results: results}
+	for _, result := range results {
+		switch {
+		case result.State == PayoutStateConfirmed && result.Status == StatusPixSendCompleted:
+			summary.Completed++
+			cents, _ := ParseAmount(result.Valor)
+			summary.CompletedCents += cents
+		case result.State == PayoutStateRejected || (result.State == PayoutStateConfirmed && result.Status == StatusPixSendFailed):
+			summary.Failed++
+		case result.State == PayoutStateSent:
+			summary.Unknown++
+		default:
+			summary.Pending++
+		}
+	}
+
+	return summary, ctx.Err()
+}
+
+func (e *PayoutEngine) send(ctx context.Context, payouts []Payout, results []PayoutResult) {
+	concurrency := e.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i := range payouts {
+		if results[i].State != PayoutStatePending {
+			continue
+		}
+
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		}
+
+		wg.Add(1)
+		go func(payout Payout, result *PayoutResult) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			e.sendOne(ctx, payout, result)
+		}(payouts[i], &results[i])
+	}
+
+	wg.Wait()
+}
+
+func (e *PayoutEngine) sendOne(ctx context.Context, payout Payout, result *PayoutResult) {
+	existing, err := e.client.PixSend().GetByIDEnvio(result.IDEnvio)
+	switch {
+	case err == nil:
+		result.State = PayoutStateSent
+		result.E2EID = existing.EndToEndID
+		result.Status = existing.Status
+		result.Error = ""
+		e.record(result)
+		return
+	case !errors.Is(err, ErrPixSendNotFound):
+		result.Error = err.Error()
+		e.record(result)
+		return
+	}
+
+	result.Error = ""
+	if err := e.record(result); err != nil {
+		result.Error = err.Error()
+		return
+	}
+
+	request := PixSendRequest{
+		Valor:      payout.Valor,
+		Pagador:    PagadorSend{Chave: e.PayerKey, InfoPagador: payout.InfoPagador},
+		Favorecido: payout.Favorecido,
+	}
+
+	for attempt := 0; attempt <= e.MaxRetries; attempt++ {
+		if err := e.waitForBucket(ctx); err != nil {
+			result.Error = err.Error()
+			return
+		}
+
+		response, rateLimit, err := e.client.PixSend().SendWithRateLimit(result.IDEnvio, request)
+		e.updateBucket(rateLimit)
+
+		if err == nil {
+			result.State = PayoutStateSent
+			result.E2EID = response.E2EID
+			result.Status = response.Status
+			result.Error = ""
+			e.record(result)
+			return
+		}
+
+		result.Error = err.Error()
+		switch {
+		case rateLimit.StatusCode == http.StatusTooManyRequests:
+			continue
+		case isDuplicatePixSend(rateLimit.StatusCode, err):
+			existing, lookupErr := e.client.PixSend().GetByIDEnvio(result.IDEnvio)
+			if lookupErr != nil {
+				result.Error = lookupErr.Error()
+				e.record(result)
+				return
+			}
+			result.State = PayoutStateSent
+			result.E2EID = existing.EndToEndID
+			result.Status = existing.Status
+			result.Error = ""
+			e.record(result)
+			return
+		case rateLimit.StatusCode >= 400 && rateLimit.StatusCode < 500:
+			result.State = PayoutStateRejected
+			e.record(result)
+			return
+		default:
+			e.record(result)
+			return
+		}
+	}
+}
+
+func isDuplicatePixSend(statusCode int, err error) bool {
+	if statusCode == http.StatusConflict {
+		return true
+	}
+	return statusCode >= 400 && statusCode < 500 && strings.Contains(strings.ToLower(err.Error()), "duplicad")
+}
+
+func (e *PayoutEngine) confirm(ctx context.Context, results []PayoutResult) {
+	for attempt := 1; ; attempt++ {
+		pending := 0
+		for i := range results {
+			result := &results[i]
+			if result.State != PayoutStateSent {
+				continue
+			}
+
+			detail, err := e.client.PixSend().GetByIDEnvio(result.IDEnvio)
+			if err != nil {
+				result.Error = err.Error()
+				pending++
+				continue
+			}
+
+			result.E2EID = detail.EndToEndID
+			result.Status = detail.Status
+			result.Error = ""
+			if detail.Status == StatusPixSendProcessing {
+				pending++
+				continue
+			}
+
+			result.State = PayoutStateConfirmed
+			e.record(result)
+		}
+
+		if pending == 0 || (e.MaxConfirmAttempts > 0 && attempt >= e.MaxConfirmAttempts) {
+			return
+		}
+
+		timer := time.NewTimer(e.ConfirmInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func (e *PayoutEngine) waitForBucket(ctx context.Context) error {
+	e.mu.Lock()
+	wait := time.Until(e.pausedUntil)
+	e.mu.Unlock()
+
+	if wait <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (e *PayoutEngine) updateBucket(rateLimit *PixSendRateLimit) {
+	var pause time.Duration
+	switch {
+	case rateLimit.RetryAfter > 0:
+		pause = rateLimit.RetryAfter
+	case rateLimit.StatusCode == http.StatusTooManyRequests:
+		pause = 5 * time.Second
+	case rateLimit.BucketSize == 0:
+		pause = time.Second
+	default:
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if until := time.Now().Add(pause); until.After(e.pausedUntil) {
+		e.pausedUntil = until
+	}
+}
+
+func (e *PayoutEngine) record(result *PayoutResult) error {
+	return e.journal.Append(PayoutJournalEntry{
+		Reference: result.Reference,
+		IDEnvio:   result.IDEnvio,
+		State:     result.State,
+		Valor:     result.Valor,
+		E2EID:     result.E2EID,
+		Status:    result.Status,
+		Error:     result.Error,
+		At:        time.Now(),
+	})
+}
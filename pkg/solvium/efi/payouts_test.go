@@ -0,0 +1,87 @@
+package efi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPayoutEngineResumesWithoutPayingTwice(t *testing.T) {
+	journal := NewMemoryPayoutJournal()
+	engine := NewPayoutEngine(nil, journal, "commissions", "payer@example.com")
+	engine.ConfirmInterval = time.Millisecond
+	engine.MaxConfirmAttempts = 3
+
+	var mu sync.Mutex
+	sent := map[string]PixSentDetail{
+		engine.IDEnvio("crashed-after-send"): {EndToEndID: "E-crashed", Status: StatusPixSendCompleted},
+	}
+	puts := make(map[string]int)
+	broken := map[string]bool{
+		engine.IDEnvio("lookup-fails"):  true,
+		engine.IDEnvio("confirm-fails"): true,
+	}
+
+	engine.client = newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		idEnvio := path.Base(r.URL.Path)
+		switch {
+		case broken[idEnvio]:
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		case r.Method == http.MethodPut && idEnvio == engine.IDEnvio("conflict"):
+			puts[idEnvio]++
+			sent[idEnvio] = PixSentDetail{EndToEndID: "E-conflict", Status: StatusPixSendCompleted}
+			http.Error(w, `{"nome":"id_envio_duplicado"}`, http.StatusConflict)
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/v3/gn/pix/"):
+			puts[idEnvio]++
+			sent[idEnvio] = PixSentDetail{EndToEndID: "E-" + idEnvio, Status: StatusPixSendCompleted}
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(PixSendResponse{IDEnvio: idEnvio, E2EID: "E-" + idEnvio, Status: StatusPixSendProcessing})
+		case r.Method == http.MethodGet:
+			detail, ok := sent[idEnvio]
+			if !ok {
+				http.Error(w, `{"nome":"nao_encontrado"}`, http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(detail)
+		}
+	}))
+
+	journal.Append(PayoutJournalEntry{Reference: "crashed-after-send", IDEnvio: engine.IDEnvio("crashed-after-send"), State: PayoutStatePending})
+	journal.Append(PayoutJournalEntry{Reference: "confirm-fails", IDEnvio: engine.IDEnvio("confirm-fails"), State: PayoutStateSent})
+
+	payouts := []Payout{
+		{Reference: "crashed-after-send", Valor: "10.00"},
+		{Reference: "new", Valor: "20.00"},
+		{Reference: "lookup-fails", Valor: "30.00"},
+		{Reference: "confirm-fails", Valor: "40.00"},
+		{Reference: "conflict", Valor: "50.00"},
+	}
+
+	summary, err := engine.Run(context.Background(), payouts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if puts[engine.IDEnvio("crashed-after-send")] != 0 || puts[engine.IDEnvio("new")] != 1 || puts[engine.IDEnvio("lookup-fails")] != 0 {
+		t.Fatalf("unexpected sends: %v", puts)
+	}
+	if summary.Completed != 3 || summary.Failed != 0 || summary.Pending != 1 || summary.Unknown != 1 || summary.CompletedCents != 8000 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+
+	summary, err = engine.Run(context.Background(), payouts[:2])
+	if err != nil {
+		t.Fatalf("unexpected error on rerun: %v", err)
+	}
+	if puts[engine.IDEnvio("new")] != 1 || summary.Completed != 2 {
+		t.Fatalf("expected the rerun to be a no-op, got sends %v and summary %+v", puts, summary)
+	}
+}
@@ -2,6 +2,7 @@ package efi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -95,6 +96,25 @@ func (p *PixManagement) ListReceived(startDate, endDate time.Time, options *List
 	return &listResp, nil
 }
 
+func (p *PixManagement) IterateReceived(startDate, endDate time.Time, options *ListReceivedOptions, pageOptions *PageOptions) *Iterator[PixDetail] {
+	return newPageIterator(pageOptions, func(ctx context.Context, page, pageSize int) ([]PixDetail, int, error) {
+		pageFilter := ListReceivedOptions{}
+		if options != nil {
+			pageFilter = *options
+		}
+		pageFilter.PaginaAtual = page
+		if pageSize > 0 {
+			pageFilter.ItensPagina = pageSize
+		}
+
+		list, err := p.ListReceived(startDate, endDate, &pageFilter)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Pix, list.Parametros.Paginacao.QuantidadeDePaginas, nil
+	})
+}
+
 func (p *PixManagement) RequestRefund(e2eID, refundID string, req RefundRequest) (*RefundResponse, error) {
 	bodyBytes, err := json.Marshal(req)
 	if err != nil {
@@ -127,6 +147,11 @@ func (p *PixManagement) GetRefund(e2eID, refundID string) (*RefundResponse, erro
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: %s", ErrRefundNotFound, body)
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		return nil, fmt.Errorf("failed to get refund with status %d: %s", resp.StatusCode, body)
@@ -14,13 +14,27 @@ type DevolucaoRefund struct {
 }
 
 type PixDetail struct {
-	EndToEndID  string            `json:"endToEndId,omitempty"`
-	TxID        string            `json:"txid,omitempty"`
-	Valor       string            `json:"valor,omitempty"`
-	Chave       string            `json:"chave,omitempty"`
-	Horario     string            `json:"horario,omitempty"`
-	InfoPagador string            `json:"infoPagador,omitempty"`
-	Devolucoes  []DevolucaoRefund `json:"devolucoes,omitempty"`
+	EndToEndID       string            `json:"endToEndId,omitempty"`
+	TxID             string            `json:"txid,omitempty"`
+	Valor            string            `json:"valor,omitempty"`
+	Chave            string            `json:"chave,omitempty"`
+	Horario          string            `json:"horario,omitempty"`
+	InfoPagador      string            `json:"infoPagador,omitempty"`
+	Devolucoes       []DevolucaoRefund `json:"devolucoes,omitempty"`
+	ComponentesValor *ComponentesValor `json:"componentesValor,omitempty"`
+	GnExtras         *PixExtras        `json:"gnExtras,omitempty"`
+}
+
+type PixPagadorExtras struct {
+	Nome        string `json:"nome,omitempty"`
+	CPF         string `json:"cpf,omitempty"`
+	CNPJ        string `json:"cnpj,omitempty"`
+	CodigoBanco string `json:"codigoBanco,omitempty"`
+}
+
+type PixExtras struct {
+	Pagador *PixPagadorExtras `json:"pagador,omitempty"`
+	Tarifa  string            `json:"tarifa,omitempty"`
 }
 
 type PixListResponse struct {
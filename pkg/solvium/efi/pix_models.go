@@ -20,8 +20,9 @@ type Devedor struct {
 
 
 type Valor struct {
-	Original   string `json:"original,omitempty"`
-	Modalidade int    `json:"modalidade,omitempty"`
+	Original   string    `json:"original,omitempty"`
+	Modalidade int       `json:"modalidade,omitempty"`
+	Retirada   *Retirada `json:"retirada,omitempty"`
 }
 
 
@@ -56,13 +57,14 @@ type Devolucao struct {
 
 
 type PixInfo struct {
-	EndToEndID  string      `json:"endToEndId,omitempty"`
-	TxID        string      `json:"txid,omitempty"`
-	Valor       string      `json:"valor,omitempty"`
-	Horario     string      `json:"horario,omitempty"`
-	Pagador     Pagador     `json:"pagador,omitempty"`
-	InfoPagador string      `json:"infoPagador,omitempty"`
-	Devolucoes  []Devolucao `json:"devolucoes,omitempty"`
+	EndToEndID       string            `json:"endToEndId,omitempty"`
+	TxID             string            `json:"txid,omitempty"`
+	Valor            string            `json:"valor,omitempty"`
+	Horario          string            `json:"horario,omitempty"`
+	Pagador          Pagador           `json:"pagador,omitempty"`
+	InfoPagador      string            `json:"infoPagador,omitempty"`
+	Devolucoes       []Devolucao       `json:"devolucoes,omitempty"`
+	ComponentesValor *ComponentesValor `json:"componentesValor,omitempty"`
 }
 
 
@@ -85,6 +87,7 @@ type Parametros struct {
 
 type CreateImmediateChargeRequest struct {
 	Calendario         Calendario      `json:"calendario,omitempty"`
+	Loc                *LocInfo        `json:"loc,omitempty"`
 	Devedor            Devedor         `json:"devedor,omitempty"`
 	Valor              Valor           `json:"valor,omitempty"`
 	Chave              string          `json:"chave,omitempty"`
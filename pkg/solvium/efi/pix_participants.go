@@ -0,0 +1,154 @@
+package efi
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+const ISPBEfi = "09089356"
+
+type PixParticipant struct {
+	ISPB      string
+	ShortName string
+	Name      string
+	Compe     string
+}
+
+type PixParticipants struct {
+	mu      sync.RWMutex
+	byISPB  map[string]PixParticipant
+	byCompe map[string]PixParticipant
+}
+
+var defaultPixParticipants = NewPixParticipants([]PixParticipant{
+	{ISPB: "00000000", ShortName: "BCO DO BRASIL S.A.", Name: "Banco do Brasil S.A.", Compe: "001"},
+	{ISPB: "90400888", ShortName: "BCO SANTANDER (BRASIL) S.A.", Name: "Banco Santander (Brasil) S.A.", Compe: "033"},
+	{ISPB: "92702067", ShortName: "BCO DO ESTADO DO RS S.A.", Name: "Banco do Estado do Rio Grande do Sul S.A.", Compe: "041"},
+	{ISPB: "00416968", ShortName: "BANCO INTER", Name: "Banco Inter S.A.", Compe: "077"},
+	{ISPB: "00360305", ShortName: "CAIXA ECONOMICA FEDERAL", Name: "Caixa Econômica Federal", Compe: "104"},
+	{ISPB: "30306294", ShortName: "BANCO BTG PACTUAL S.A.", Name: "Banco BTG Pactual S.A.", Compe: "208"},
+	{ISPB: "92894922", ShortName: "BANCO ORIGINAL", Name: "Banco Original S.A.", Compe: "212"},
+	{ISPB: "60746948", ShortName: "BCO BRADESCO S.A.", Name: "Banco Bradesco S.A.", Compe: "237"},
+	{ISPB: "18236120", ShortName: "NU PAGAMENTOS - IP", Name: "Nu Pagamentos S.A. - Instituição de Pagamento", Compe: "260"},
+	{ISPB: "08561701", ShortName: "PAGSEGURO INTERNET IP S.A.", Name: "PagSeguro Internet Instituição de Pagamento S.A.", Compe: "290"},
+	{ISPB: "10573521", ShortName: "MERCADO PAGO IP LTDA.", Name: "Mercado Pago Instituição de Pagamento Ltda.", Compe: "323"},
+	{ISPB: "31872495", ShortName: "BCO C6 S.A.", Name: "Banco C6 S.A.", Compe: "336"},
+	{ISPB: "60701190", ShortName: "ITAÚ UNIBANCO S.A.", Name: "Itaú Unibanco S.A.", Compe: "341"},
+	{ISPB: ISPBEfi, ShortName: "EFÍ S.A. - IP", Name: "Efí S.A. - Instituição de Pagamento", Compe: "364"},
+	{ISPB: "58160789", ShortName: "BCO SAFRA S.A.", Name: "Banco Safra S.A.", Compe: "422"},
+	{ISPB: "01181521", ShortName: "BCO COOPERATIVO SICREDI S.A.", Name: "Banco Cooperativo Sicredi S.A.", Compe: "748"},
+	{ISPB: "02038232", ShortName: "BANCO SICOOB S.A.", Name: "Banco Cooperativo Sicoob S.A.", Compe: "756"},
+})
+
+func NewPixParticipants(participants []PixParticipant) *PixParticipants {
+	registry := &PixParticipants{
+		byISPB:  make(map[string]PixParticipant, len(participants)),
+		byCompe: make(map[string]PixParticipant, len(participants)),
+	}
+	registry.Add(participants...)
+	return registry
+}
+
+func DefaultPixParticipants() *PixParticipants {
+	return defaultPixParticipants
+}
+
+func (p *PixParticipants) Add(participants ...PixParticipant) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, participant := range participants {
+		p.byISPB[participant.ISPB] = participant
+		if participant.Compe != "" {
+			p.byCompe[participant.Compe] = participant
+		}
+	}
+}
+
+func (p *PixParticipants) ByISPB(ispb string) (PixParticipant, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	participant, ok := p.byISPB[strings.TrimSpace(ispb)]
+	return participant, ok
+}
+
+func (p *PixParticipants) ByCompe(code string) (PixParticipant, bool) {
+	code = strings.TrimSpace(code)
+	for len(code) < 3 {
+		code = "0" + code
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	participant, ok := p.byCompe[code]
+	return participant, ok
+}
+
+func (p *PixParticipants) Search(name string) []PixParticipant {
+	name = strings.ToUpper(strings.TrimSpace(name))
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var results []PixParticipant
+	for _, participant := range p.byISPB {
+		if strings.Contains(strings.ToUpper(participant.ShortName), name) || strings.Contains(strings.ToUpper(participant.Name), name) {
+			results = append(results, participant)
+		}
+	}
+	return results
+}
+
+func (p *PixParticipants) LoadCSV(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return fmt.Errorf("failed to read participants CSV: %w", err)
+	}
+
+	var participants []PixParticipant
+	for i, record := range records {
+		if i == 0 || len(record) < 6 {
+			continue
+		}
+
+		ispb := strings.TrimSpace(record[0])
+		for len(ispb) < 8 {
+			ispb = "0" + ispb
+		}
+		if !ispbPattern.MatchString(ispb) {
+			continue
+		}
+
+		compe := strings.TrimSpace(record[2])
+		if compe == "n/a" {
+			compe = ""
+		}
+
+		participants = append(participants, PixParticipant{
+			ISPB:      ispb,
+			ShortName: strings.TrimSpace(record[1]),
+			Compe:     compe,
+			Name:      strings.TrimSpace(record[5]),
+		})
+	}
+
+	p.Add(participants...)
+	return nil
+}
+
+func LookupISPB(ispb string) (PixParticipant, bool) {
+	return defaultPixParticipants.ByISPB(ispb)
+}
+
+func LookupISPBByCompe(code string) (string, bool) {
+	participant, ok := defaultPixParticipants.ByCompe(code)
+	return participant.ISPB, ok
+}
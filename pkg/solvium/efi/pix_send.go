@@ -2,11 +2,13 @@ package efi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -24,38 +26,43 @@ func NewPixSend(client *Client) *PixSend {
 
 
 func (p *PixSend) Send(idEnvio string, req PixSendRequest) (*PixSendResponse, error) {
+	pixResp, _, err := p.SendWithRateLimit(idEnvio, req)
+	return pixResp, err
+}
+
+func (p *PixSend) SendWithRateLimit(idEnvio string, req PixSendRequest) (*PixSendResponse, *PixSendRateLimit, error) {
+	rateLimit := &PixSendRateLimit{BucketSize: -1}
+
 	bodyBytes, err := json.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return nil, rateLimit, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	resp, err := p.client.Request("PUT", fmt.Sprintf("/v3/gn/pix/%s", idEnvio), bytes.NewReader(bodyBytes))
 	if err != nil {
-		return nil, fmt.Errorf("failed to send Pix: %w", err)
+		return nil, rateLimit, fmt.Errorf("failed to send Pix: %w", err)
 	}
 	defer resp.Body.Close()
 
+	rateLimit.StatusCode = resp.StatusCode
+	if bucketSize, err := strconv.Atoi(resp.Header.Get("Bucket-Size")); err == nil {
+		rateLimit.BucketSize = bucketSize
+	}
+	if retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		rateLimit.RetryAfter = time.Duration(retryAfter) * time.Second
+	}
+
 	if resp.StatusCode != http.StatusCreated {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to send Pix with status %d: %s", resp.StatusCode, body)
+		return nil, rateLimit, fmt.Errorf("failed to send Pix with status %d: %s", resp.StatusCode, body)
 	}
 
 	var pixResp PixSendResponse
 	if err := json.NewDecoder(resp.Body).Decode(&pixResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	
-	if bucketSize := resp.Header.Get("Bucket-Size"); bucketSize != "" {
-		fmt.Printf("Pix sending bucket size: %s\n", bucketSize)
+		return nil, rateLimit, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	
-	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
-		fmt.Printf("Retry sending after %s seconds\n", retryAfter)
-	}
-
-	return &pixResp, nil
+	return &pixResp, rateLimit, nil
 }
 
 
@@ -66,6 +73,11 @@ func (p *PixSend) GetByE2EID(e2eID string) (*PixSentDetail, error) {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: %s", ErrPixSendNotFound, body)
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		return nil, fmt.Errorf("failed to get sent Pix with status %d: %s", resp.StatusCode, body)
@@ -87,6 +99,11 @@ func (p *PixSend) GetByIDEnvio(idEnvio string) (*PixSentDetail, error) {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: %s", ErrPixSendNotFound, body)
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		return nil, fmt.Errorf("failed to get sent Pix with status %d: %s", resp.StatusCode, body)
@@ -102,10 +119,12 @@ func (p *PixSend) GetByIDEnvio(idEnvio string) (*PixSentDetail, error) {
 
 
 type ListSentOptions struct {
-	Status      string
-	InfoPagador string
-	CPF         string
-	CNPJ        string
+	Status         string
+	InfoPagador    string
+	CPF            string
+	CNPJ           string
+	PaginaAtual    int
+	ItensPorPagina int
 }
 
 
@@ -127,6 +146,12 @@ func (p *PixSend) List(startDate, endDate time.Time, options *ListSentOptions) (
 		if options.CNPJ != "" {
 			query.Add("cnpj", options.CNPJ)
 		}
+		if options.PaginaAtual > 0 {
+			query.Add("paginacao.paginaAtual", fmt.Sprintf("%d", options.PaginaAtual))
+		}
+		if options.ItensPorPagina > 0 {
+			query.Add("paginacao.itensPorPagina", fmt.Sprintf("%d", options.ItensPorPagina))
+		}
 	}
 
 	path := fmt.Sprintf("/v2/gn/pix/enviados?%s", query.Encode())
@@ -149,6 +174,25 @@ func (p *PixSend) List(startDate, endDate time.Time, options *ListSentOptions) (
 	return &listResp, nil
 }
 
+func (p *PixSend) Iterate(startDate, endDate time.Time, options *ListSentOptions, pageOptions *PageOptions) *Iterator[PixSentDetail] {
+	return newPageIterator(pageOptions, func(ctx context.Context, page, pageSize int) ([]PixSentDetail, int, error) {
+		pageFilter := ListSentOptions{}
+		if options != nil {
+			pageFilter = *options
+		}
+		pageFilter.PaginaAtual = page
+		if pageSize > 0 {
+			pageFilter.ItensPorPagina = pageSize
+		}
+
+		list, err := p.List(startDate, endDate, &pageFilter)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Pix, list.Parametros.Paginacao.QuantidadeDePaginas, nil
+	})
+}
+
 
 func (p *PixSend) DetailQRCode(req DetailQRCodeRequest) (*QRCodeDetail, error) {
 	bodyBytes, err := json.Marshal(req)
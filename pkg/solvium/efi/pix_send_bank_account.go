@@ -0,0 +1,87 @@
+package efi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	idEnvioPattern = regexp.MustCompile(`^[a-zA-Z0-9]{1,35}$`)
+	ispbPattern    = regexp.MustCompile(`^[0-9]{8}$`)
+	digitsPattern  = regexp.MustCompile(`^[0-9]+$`)
+)
+
+func ValidateIDEnvio(idEnvio string) error {
+	if !idEnvioPattern.MatchString(idEnvio) {
+		return fmt.Errorf("invalid idEnvio %q: must have 1 to 35 alphanumeric characters", idEnvio)
+	}
+	return nil
+}
+
+func (c ContaBanco) Validate() error {
+	if !ispbPattern.MatchString(c.Codigo) {
+		return fmt.Errorf("invalid ISPB %q: must have 8 digits", c.Codigo)
+	}
+	if len(c.Agencia) == 0 || len(c.Agencia) > 4 || !digitsPattern.MatchString(c.Agencia) {
+		return fmt.Errorf("invalid agency %q: must have 1 to 4 digits", c.Agencia)
+	}
+	if len(c.Conta) == 0 || len(c.Conta) > 20 || !digitsPattern.MatchString(c.Conta) {
+		return fmt.Errorf("invalid account %q: must have 1 to 20 digits, including the check digit", c.Conta)
+	}
+	if (c.CPF == "") == (c.CNPJ == "") {
+		return fmt.Errorf("exactly one of CPF or CNPJ must be informed for the account holder")
+	}
+	if c.CPF != "" && (len(c.CPF) != 11 || !digitsPattern.MatchString(c.CPF)) {
+		return fmt.Errorf("invalid CPF %q", c.CPF)
+	}
+	if c.CNPJ != "" && (len(c.CNPJ) != 14 || !digitsPattern.MatchString(c.CNPJ)) {
+		return fmt.Errorf("invalid CNPJ %q", c.CNPJ)
+	}
+
+	switch c.TipoConta {
+	case ContaBancoCorrente, ContaBancoPoupanca, ContaBancoSalario, ContaBancoPagamento:
+	default:
+		return fmt.Errorf("invalid account type %q", c.TipoConta)
+	}
+
+	return nil
+}
+
+func (p *PixSend) SendToBankAccount(idEnvio, valor string, pagador PagadorSend, conta ContaBanco) (*PixSendResponse, error) {
+	if err := ValidateIDEnvio(idEnvio); err != nil {
+		return nil, err
+	}
+	if err := conta.Validate(); err != nil {
+		return nil, err
+	}
+
+	return p.Send(idEnvio, PixSendRequest{
+		Valor:   valor,
+		Pagador: pagador,
+		Favorecido: Favorecido{
+			ContaBanco: &conta,
+		},
+	})
+}
+
+func (p *PixSend) SendToOwnAccount(idEnvio, valor string, pagador PagadorSend, ownerDocument string, conta ContaBanco) (*PixSendResponse, error) {
+	ownerDocument = strings.NewReplacer(".", "", "-", "", "/", "").Replace(ownerDocument)
+
+	switch len(ownerDocument) {
+	case 11:
+		if conta.CNPJ != "" || (conta.CPF != "" && conta.CPF != ownerDocument) {
+			return nil, fmt.Errorf("account holder does not match owner document %s", ownerDocument)
+		}
+		conta.CPF = ownerDocument
+	case 14:
+		if conta.CPF != "" || (conta.CNPJ != "" && conta.CNPJ != ownerDocument) {
+			return nil, fmt.Errorf("account holder does not match owner document %s", ownerDocument)
+		}
+		conta.CNPJ = ownerDocument
+	default:
+		return nil, fmt.Errorf("invalid owner document %q", ownerDocument)
+	}
+
+	return p.SendToBankAccount(idEnvio, valor, pagador, conta)
+}
@@ -1,5 +1,7 @@
 package efi
 
+import "time"
+
 type Horario struct {
 	Solicitacao string `json:"solicitacao,omitempty"`
 	Liquidacao  string `json:"liquidacao,omitempty"`
@@ -10,9 +12,29 @@ type PagadorSend struct {
 	InfoPagador string `json:"infoPagador,omitempty"`
 }
 
+type ContaBancoTipo string
+
+const (
+	ContaBancoCorrente  ContaBancoTipo = "cacc"
+	ContaBancoPoupanca  ContaBancoTipo = "svgs"
+	ContaBancoSalario   ContaBancoTipo = "slrc"
+	ContaBancoPagamento ContaBancoTipo = "tran"
+)
+
 type Favorecido struct {
 	Chave         string        `json:"chave,omitempty"`
 	Identificacao Identificacao `json:"identificacao,omitempty"`
+	ContaBanco    *ContaBanco   `json:"contaBanco,omitempty"`
+}
+
+type ContaBanco struct {
+	Nome      string         `json:"nome,omitempty"`
+	CPF       string         `json:"cpf,omitempty"`
+	CNPJ      string         `json:"cnpj,omitempty"`
+	Codigo    string         `json:"codigoBanco"`
+	Agencia   string         `json:"agencia"`
+	Conta     string         `json:"conta"`
+	TipoConta ContaBancoTipo `json:"tipoConta"`
 }
 
 type Identificacao struct {
@@ -72,3 +94,9 @@ type PayQRCodeRequest struct {
 	Pagador       PagadorSend `json:"pagador,omitempty"`
 	PixCopiaECola string      `json:"pixCopiaECola,omitempty"`
 }
+
+type PixSendRateLimit struct {
+	StatusCode int
+	BucketSize int
+	RetryAfter time.Duration
+}
@@ -0,0 +1,19 @@
+package efi
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+func ParsePixWebhookCallback(payload []byte) (*PixWebhookCallback, error) {
+	var callback PixWebhookCallback
+	if err := json.Unmarshal(payload, &callback); err != nil {
+		return nil, fmt.Errorf("failed to parse pix webhook callback: %w", err)
+	}
+
+	return &callback, nil
+}
+
+func (p PixWebhookPix) IsSend() bool {
+	return p.Tipo == PixWebhookTypeSend || (p.GnExtras != nil && p.GnExtras.IDEnvio != "")
+}
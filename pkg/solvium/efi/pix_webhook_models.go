@@ -0,0 +1,35 @@
+package efi
+
+const (
+	PixWebhookTypeSend    = "SOLICITACAO"
+	PixWebhookTypeReceipt = "RECEBIMENTO"
+)
+
+type PixWebhookError struct {
+	Codigo string `json:"codigo,omitempty"`
+	Motivo string `json:"motivo,omitempty"`
+}
+
+type PixWebhookExtras struct {
+	IDEnvio string            `json:"idEnvio,omitempty"`
+	Pagador *PixPagadorExtras `json:"pagador,omitempty"`
+	Tarifa  string            `json:"tarifa,omitempty"`
+	Erro    *PixWebhookError  `json:"erro,omitempty"`
+}
+
+type PixWebhookPix struct {
+	EndToEndID  string            `json:"endToEndId,omitempty"`
+	TxID        string            `json:"txid,omitempty"`
+	Chave       string            `json:"chave,omitempty"`
+	Valor       string            `json:"valor,omitempty"`
+	Horario     string            `json:"horario,omitempty"`
+	InfoPagador string            `json:"infoPagador,omitempty"`
+	Tipo        string            `json:"tipo,omitempty"`
+	Status      string            `json:"status,omitempty"`
+	Devolucoes  []DevolucaoRefund `json:"devolucoes,omitempty"`
+	GnExtras    *PixWebhookExtras `json:"gnExtras,omitempty"`
+}
+
+type PixWebhookCallback struct {
+	Pix []PixWebhookPix `json:"pix"`
+}
@@ -0,0 +1,97 @@
+package efi
+
+import "fmt"
+
+func (v Valor) Validate() error {
+	if v.Retirada == nil {
+		return nil
+	}
+
+	saque, troco := v.Retirada.Saque, v.Retirada.Troco
+	switch {
+	case saque == nil && troco == nil:
+		return fmt.Errorf("retirada must contain saque or troco")
+	case saque != nil && troco != nil:
+		return fmt.Errorf("retirada cannot contain both saque and troco")
+	}
+
+	original, err := ParseAmount(v.Original)
+	if err != nil {
+		return fmt.Errorf("invalid valor.original: %w", err)
+	}
+
+	if saque != nil {
+		if original != 0 {
+			return fmt.Errorf("valor.original must be 0.00 for Pix Saque")
+		}
+		if v.Modalidade != 0 {
+			return fmt.Errorf("valor.modalidadeAlteracao must be 0 for Pix Saque")
+		}
+		return saque.validate("saque", ModalidadeAgenteEstabelecimento, ModalidadeAgenteOutraEspecie, ModalidadeAgenteFacilitador)
+	}
+
+	if original <= 0 {
+		return fmt.Errorf("valor.original must be greater than zero for Pix Troco")
+	}
+	if v.Modalidade != 0 {
+		return fmt.Errorf("valor.modalidadeAlteracao must be 0 for Pix Troco")
+	}
+	return troco.validate("troco", ModalidadeAgenteEstabelecimento, ModalidadeAgenteOutraEspecie)
+}
+
+func (r *RetiradaValor) validate(kind string, allowed ...ModalidadeAgente) error {
+	amount, err := ParseAmount(r.Valor)
+	if err != nil {
+		return fmt.Errorf("invalid %s.valor: %w", kind, err)
+	}
+	if amount <= 0 && r.ModalidadeAlteracao != 1 {
+		return fmt.Errorf("%s.valor must be greater than zero unless modalidadeAlteracao is 1", kind)
+	}
+	if amount < 0 {
+		return fmt.Errorf("%s.valor cannot be negative", kind)
+	}
+
+	if r.ModalidadeAlteracao != 0 && r.ModalidadeAlteracao != 1 {
+		return fmt.Errorf("%s.modalidadeAlteracao must be 0 or 1", kind)
+	}
+
+	valid := false
+	for _, modalidade := range allowed {
+		if r.ModalidadeAgente == modalidade {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("%s.modalidadeAgente %q is not allowed", kind, r.ModalidadeAgente)
+	}
+
+	if !ispbPattern.MatchString(r.PrestadorDoServicoDeSaque) {
+		return fmt.Errorf("%s.prestadorDoServicoDeSaque must be an 8-digit ISPB", kind)
+	}
+
+	return nil
+}
+
+func (c *ComponentesValor) SaqueAmount() (int64, error) {
+	return componenteAmount(c.Saque)
+}
+
+func (c *ComponentesValor) TrocoAmount() (int64, error) {
+	return componenteAmount(c.Troco)
+}
+
+func (c *ComponentesValor) OriginalAmount() (int64, error) {
+	return componenteAmount(c.Original)
+}
+
+func (c *ComponentesValor) IsWithdrawal() bool {
+	return c != nil && (c.Saque != nil || c.Troco != nil)
+}
+
+func componenteAmount(componente *ComponenteValor) (int64, error) {
+	if componente == nil || componente.Valor == "" {
+		return 0, nil
+	}
+	return ParseAmount(componente.Valor)
+}
@@ -0,0 +1,37 @@
+package efi
+
+type ModalidadeAgente string
+
+const (
+	ModalidadeAgenteEstabelecimento ModalidadeAgente = "AGTEC"
+	ModalidadeAgenteOutraEspecie    ModalidadeAgente = "AGTOT"
+	ModalidadeAgenteFacilitador     ModalidadeAgente = "AGPSS"
+)
+
+type RetiradaValor struct {
+	Valor                     string           `json:"valor"`
+	ModalidadeAlteracao       int              `json:"modalidadeAlteracao,omitempty"`
+	ModalidadeAgente          ModalidadeAgente `json:"modalidadeAgente"`
+	PrestadorDoServicoDeSaque string           `json:"prestadorDoServicoDeSaque"`
+}
+
+type Retirada struct {
+	Saque *RetiradaValor `json:"saque,omitempty"`
+	Troco *RetiradaValor `json:"troco,omitempty"`
+}
+
+type ComponenteValor struct {
+	Valor                     string           `json:"valor,omitempty"`
+	ModalidadeAgente          ModalidadeAgente `json:"modalidadeAgente,omitempty"`
+	PrestadorDeServicoDeSaque string           `json:"prestadorDeServicoDeSaque,omitempty"`
+}
+
+type ComponentesValor struct {
+	Original   *ComponenteValor `json:"original,omitempty"`
+	Saque      *ComponenteValor `json:"saque,omitempty"`
+	Troco      *ComponenteValor `json:"troco,omitempty"`
+	Juros      *ComponenteValor `json:"juros,omitempty"`
+	Multa      *ComponenteValor `json:"multa,omitempty"`
+	Abatimento *ComponenteValor `json:"abatimento,omitempty"`
+	Desconto   *ComponenteValor `json:"desconto,omitempty"`
+}
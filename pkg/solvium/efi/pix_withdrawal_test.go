@@ -0,0 +1,38 @@
+package efi
+
+import "testing"
+
+func TestValorValidateRetirada(t *testing.T) {
+	saque := &RetiradaValor{Valor: "50.00", ModalidadeAgente: ModalidadeAgenteEstabelecimento, PrestadorDoServicoDeSaque: ISPBEfi}
+	troco := &RetiradaValor{Valor: "20.00", ModalidadeAgente: ModalidadeAgenteOutraEspecie, PrestadorDoServicoDeSaque: ISPBEfi}
+
+	tests := []struct {
+		name  string
+		valor Valor
+		ok    bool
+	}{
+		{"no retirada", Valor{Original: "10.00"}, true},
+		{"saque", Valor{Original: "0.00", Retirada: &Retirada{Saque: saque}}, true},
+		{"saque with original", Valor{Original: "10.00", Retirada: &Retirada{Saque: saque}}, false},
+		{"troco", Valor{Original: "30.00", Retirada: &Retirada{Troco: troco}}, true},
+		{"troco without original", Valor{Original: "0.00", Retirada: &Retirada{Troco: troco}}, false},
+		{"both", Valor{Original: "0.00", Retirada: &Retirada{Saque: saque, Troco: troco}}, false},
+		{"empty retirada", Valor{Original: "0.00", Retirada: &Retirada{}}, false},
+		{"troco with facilitator", Valor{Original: "30.00", Retirada: &Retirada{Troco: &RetiradaValor{
+			Valor: "20.00", ModalidadeAgente: ModalidadeAgenteFacilitador, PrestadorDoServicoDeSaque: ISPBEfi,
+		}}}, false},
+		{"invalid ispb", Valor{Original: "0.00", Retirada: &Retirada{Saque: &RetiradaValor{
+			Valor: "50.00", ModalidadeAgente: ModalidadeAgenteEstabelecimento, PrestadorDoServicoDeSaque: "123",
+		}}}, false},
+	}
+
+	for _, tt := range tests {
+		err := tt.valor.Validate()
+		if tt.ok && err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+		}
+		if !tt.ok && err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
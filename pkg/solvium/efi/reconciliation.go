@@ -0,0 +1,313 @@
+package efi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Reconciler struct {
+	client *Client
+
+	PageSize int
+}
+
+func NewReconciler(client *Client) *Reconciler {
+	return &Reconciler{
+		client:   client,
+		PageSize: 100,
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, startDate, endDate time.Time, expected []ExpectedReceivable) (*ReconciliationReport, error) {
+	charges, err := r.fetchCharges(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	received, err := r.fetchReceived(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := ReconcileReceivables(expected, charges, received)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Start = startDate
+	report.End = endDate
+	return report, nil
+}
+
+func (r *Reconciler) fetchCharges(ctx context.Context, startDate, endDate time.Time) ([]ReconciledCharge, error) {
+	var charges []ReconciledCharge
+
+	for page := 0; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		list, err := r.client.ImmediateCharge().ListCharges(startDate, endDate, &ListChargesOptions{PaginaAtual: page, ItensPorPagina: r.PageSize})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, cob := range list.Cobs {
+			charges = append(charges, ReconciledCharge{
+				TxID:     cob.TxID,
+				Type:     TransactionTypeCharge,
+				Status:   cob.Status,
+				Amount:   cob.Valor.Original,
+				Document: firstNonEmpty(cob.Devedor.CPF, cob.Devedor.CNPJ),
+			})
+		}
+
+		if page+1 >= list.Parametros.Paginacao.QuantidadeDePaginas {
+			break
+		}
+	}
+
+	for page := 0; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		list, err := r.client.DueCharge().List(startDate, endDate, &ListDueChargesOptions{PaginaAtual: page, ItensPorPagina: r.PageSize})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, cobv := range list.Cobs {
+			charges = append(charges, ReconciledCharge{
+				TxID:     cobv.TxID,
+				Type:     TransactionTypeDueCharge,
+				Status:   cobv.Status,
+				Amount:   cobv.Valor.Original,
+				Document: firstNonEmpty(cobv.Devedor.CPF, cobv.Devedor.CNPJ),
+			})
+		}
+
+		if page+1 >= list.Parametros.Paginacao.QuantidadeDePaginas {
+			break
+		}
+	}
+
+	return charges, nil
+}
+
+func (r *Reconciler) fetchReceived(ctx context.Context, startDate, endDate time.Time) ([]PixDetail, error) {
+	var received []PixDetail
+
+	for page := 0; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		list, err := r.client.PixManagement().ListReceived(startDate, endDate, &ListReceivedOptions{PaginaAtual: page, ItensPagina: r.PageSize})
+		if err != nil {
+			return nil, err
+		}
+
+		received = append(received, list.Pix...)
+
+		if page+1 >= list.Parametros.Paginacao.QuantidadeDePaginas {
+			break
+		}
+	}
+
+	return received, nil
+}
+
+type reconciledPix struct {
+	pix      PixDetail
+	gross    int64
+	refunded int64
+	claimed  bool
+}
+
+func ReconcileReceivables(expected []ExpectedReceivable, charges []ReconciledCharge, received []PixDetail) (*ReconciliationReport, error) {
+	report := &ReconciliationReport{Counts: make(map[ReconciliationCategory]int)}
+
+	chargesByTxID := make(map[string]*ReconciledCharge, len(charges))
+	for i := range charges {
+		chargesByTxID[charges[i].TxID] = &charges[i]
+	}
+
+	pixByTxID := make(map[string][]*reconciledPix)
+	all := make([]*reconciledPix, 0, len(received))
+	for _, pix := range received {
+		gross, err := ParseAmount(pix.Valor)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount on Pix %s: %w", pix.EndToEndID, err)
+		}
+
+		item := &reconciledPix{pix: pix, gross: gross}
+		for _, refund := range pix.Devolucoes {
+			if refund.Status != StatusRefundCompleted {
+				continue
+			}
+			cents, err := ParseAmount(refund.Valor)
+			if err != nil {
+				return nil, fmt.Errorf("invalid refund amount on Pix %s: %w", pix.EndToEndID, err)
+			}
+			item.refunded += cents
+		}
+
+		all = append(all, item)
+		if pix.TxID != "" {
+			pixByTxID[pix.TxID] = append(pixByTxID[pix.TxID], item)
+		}
+	}
+
+	expectedTxIDs := make(map[string]bool, len(expected))
+	for _, receivable := range expected {
+		if receivable.TxID != "" {
+			expectedTxIDs[receivable.TxID] = true
+		}
+	}
+
+	for i := range expected {
+		receivable := &expected[i]
+		entry := ReconciliationEntry{Expected: receivable, TxID: receivable.TxID}
+
+		if charge, ok := chargesByTxID[receivable.TxID]; ok {
+			entry.Charge = charge
+		}
+
+		amount := receivable.Amount
+		if amount == "" && entry.Charge != nil {
+			amount = entry.Charge.Amount
+		}
+		if amount == "" {
+			return nil, fmt.Errorf("expected receivable %q has no amount", receivable.Reference)
+		}
+		expectedCents, err := ParseAmount(amount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount for expected receivable %q: %w", receivable.Reference, err)
+		}
+		entry.ExpectedCents = expectedCents
+
+		document := receivable.Document
+		if document == "" && entry.Charge != nil {
+			document = entry.Charge.Document
+		}
+
+		var matched []*reconciledPix
+		if receivable.TxID != "" {
+			matched = pixByTxID[receivable.TxID]
+		}
+		if len(matched) == 0 && document != "" {
+			for _, item := range all {
+				if item.claimed || (item.pix.TxID != "" && expectedTxIDs[item.pix.TxID]) {
+					continue
+				}
+				if item.gross == expectedCents && documentsMatch(document, pixPayerDocument(item.pix)) {
+					matched = []*reconciledPix{item}
+					entry.Note = "matched by amount and payer document"
+					break
+				}
+			}
+		}
+
+		effective := 0
+		for _, item := range matched {
+			item.claimed = true
+			entry.Pix = append(entry.Pix, item.pix)
+			entry.ReceivedCents += item.gross
+			entry.RefundedCents += item.refunded
+			if item.gross > item.refunded {
+				effective++
+			}
+
+			if payer := pixPayerDocument(item.pix); document != "" && payer != "" && !documentsMatch(document, payer) {
+				entry.DocumentMismatch = true
+			}
+		}
+		entry.NetCents = entry.ReceivedCents - entry.RefundedCents
+
+		switch {
+		case effective == 0:
+			entry.Category = ReconciliationUnpaid
+			if len(matched) > 0 {
+				entry.Note = "all received Pix were refunded"
+			}
+		case effective > 1:
+			entry.Category = ReconciliationDuplicatePaid
+		case entry.NetCents == expectedCents:
+			entry.Category = ReconciliationMatched
+		case entry.NetCents > expectedCents:
+			entry.Category = ReconciliationOverpaid
+		default:
+			entry.Category = ReconciliationUnderpaid
+		}
+
+		report.add(entry)
+	}
+
+	for _, item := range all {
+		if item.claimed {
+			continue
+		}
+
+		entry := ReconciliationEntry{
+			Category:      ReconciliationOrphan,
+			TxID:          item.pix.TxID,
+			Pix:           []PixDetail{item.pix},
+			ReceivedCents: item.gross,
+			RefundedCents: item.refunded,
+			NetCents:      item.gross - item.refunded,
+		}
+		if charge, ok := chargesByTxID[item.pix.TxID]; ok {
+			entry.Charge = charge
+			entry.Note = "charge exists but was not expected"
+		}
+
+		report.add(entry)
+	}
+
+	return report, nil
+}
+
+func (r *ReconciliationReport) add(entry ReconciliationEntry) {
+	r.Entries = append(r.Entries, entry)
+	r.Counts[entry.Category]++
+}
+
+func pixPayerDocument(pix PixDetail) string {
+	if pix.GnExtras == nil || pix.GnExtras.Pagador == nil {
+		return ""
+	}
+	return firstNonEmpty(pix.GnExtras.Pagador.CPF, pix.GnExtras.Pagador.CNPJ)
+}
+
+func documentsMatch(expected, received string) bool {
+	expected = onlyDigits(expected)
+	received = strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == '*' {
+			return r
+		}
+		return -1
+	}, received)
+
+	if expected == "" || received == "" || len(expected) != len(received) {
+		return false
+	}
+
+	for i := range expected {
+		if received[i] != '*' && received[i] != expected[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,60 @@
+package efi
+
+import "time"
+
+type ReconciliationCategory string
+
+const (
+	ReconciliationMatched       ReconciliationCategory = "matched"
+	ReconciliationUnpaid        ReconciliationCategory = "unpaid"
+	ReconciliationOverpaid      ReconciliationCategory = "overpaid"
+	ReconciliationUnderpaid     ReconciliationCategory = "underpaid"
+	ReconciliationDuplicatePaid ReconciliationCategory = "duplicate_paid"
+	ReconciliationOrphan        ReconciliationCategory = "orphan"
+)
+
+type ExpectedReceivable struct {
+	Reference string
+	TxID      string
+	Amount    string
+	Document  string
+}
+
+type ReconciledCharge struct {
+	TxID     string
+	Type     TransactionType
+	Status   string
+	Amount   string
+	Document string
+}
+
+type ReconciliationEntry struct {
+	Category         ReconciliationCategory
+	Expected         *ExpectedReceivable
+	Charge           *ReconciledCharge
+	TxID             string
+	Pix              []PixDetail
+	ExpectedCents    int64
+	ReceivedCents    int64
+	RefundedCents    int64
+	NetCents         int64
+	DocumentMismatch bool
+	Note             string
+}
+
+type ReconciliationReport struct {
+	Start   time.Time
+	End     time.Time
+	Entries []ReconciliationEntry
+	Counts  map[ReconciliationCategory]int
+}
+
+func (r *ReconciliationReport) ByCategory(category ReconciliationCategory) []ReconciliationEntry {
+	var entries []ReconciliationEntry
+	for _, entry := range r.Entries {
+		if entry.Category == category {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
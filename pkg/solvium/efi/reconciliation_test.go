@@ -0,0 +1,59 @@
+package efi
+
+import "testing"
+
+func TestReconcileReceivables(t *testing.T) {
+	expected := []ExpectedReceivable{
+		{Reference: "matched", TxID: "tx-matched", Amount: "10.00"},
+		{Reference: "unpaid", TxID: "tx-unpaid", Amount: "20.00"},
+		{Reference: "overpaid", TxID: "tx-overpaid", Amount: "5.00"},
+		{Reference: "underpaid", TxID: "tx-underpaid", Amount: "30.00"},
+		{Reference: "duplicate", TxID: "tx-duplicate", Amount: "7.50"},
+		{Reference: "refunded", TxID: "tx-refunded", Amount: "12.00"},
+		{Reference: "by-document", Amount: "42.00", Document: "123.456.789-09"},
+	}
+
+	received := []PixDetail{
+		{EndToEndID: "E1", TxID: "tx-matched", Valor: "10.00"},
+		{EndToEndID: "E2", TxID: "tx-overpaid", Valor: "6.00"},
+		{EndToEndID: "E3", TxID: "tx-underpaid", Valor: "35.00", Devolucoes: []DevolucaoRefund{
+			{ID: "D1", Valor: "10.00", Status: StatusRefundCompleted},
+		}},
+		{EndToEndID: "E4", TxID: "tx-duplicate", Valor: "7.50"},
+		{EndToEndID: "E5", TxID: "tx-duplicate", Valor: "7.50"},
+		{EndToEndID: "E6", TxID: "tx-refunded", Valor: "12.00", Devolucoes: []DevolucaoRefund{
+			{ID: "D2", Valor: "12.00", Status: StatusRefundCompleted},
+		}},
+		{EndToEndID: "E7", Valor: "42.00", GnExtras: &PixExtras{Pagador: &PixPagadorExtras{CPF: "***.456.789-**"}}},
+		{EndToEndID: "E8", TxID: "tx-unknown", Valor: "99.00"},
+	}
+
+	report, err := ReconcileReceivables(expected, nil, received)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]ReconciliationCategory{
+		"matched":     ReconciliationMatched,
+		"unpaid":      ReconciliationUnpaid,
+		"overpaid":    ReconciliationOverpaid,
+		"underpaid":   ReconciliationUnderpaid,
+		"duplicate":   ReconciliationDuplicatePaid,
+		"refunded":    ReconciliationUnpaid,
+		"by-document": ReconciliationMatched,
+	}
+
+	for _, entry := range report.Entries {
+		if entry.Expected == nil {
+			continue
+		}
+		if got := entry.Category; got != want[entry.Expected.Reference] {
+			t.Errorf("%s: expected %s, got %s", entry.Expected.Reference, want[entry.Expected.Reference], got)
+		}
+	}
+
+	orphans := report.ByCategory(ReconciliationOrphan)
+	if len(orphans) != 1 || orphans[0].TxID != "tx-unknown" {
+		t.Fatalf("expected one orphan for tx-unknown, got %+v", orphans)
+	}
+}
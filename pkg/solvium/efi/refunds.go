@@ -0,0 +1,242 @@
+package efi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var ErrRefundExceedsBalance = errors.New("refund exceeds refundable balance")
+
+type RefundOutcome struct {
+	EndToEndID string
+	RefundID   string
+	Valor      string
+	Refund     *RefundResponse
+	Err        error
+}
+
+type Refunds struct {
+	client *Client
+
+	mu       sync.Mutex
+	reserved map[string]map[string]int64
+}
+
+func NewRefunds(client *Client) *Refunds {
+	return &Refunds{
+		client:   client,
+		reserved: make(map[string]map[string]int64),
+	}
+}
+
+func RefundIDFor(e2eID, key string) string {
+	sum := sha256.Sum256([]byte(e2eID + ":" + key))
+	return hex.EncodeToString(sum[:])[:32]
+}
+
+func RefundableAmount(pix PixDetail) (int64, error) {
+	gross, err := ParseAmount(pix.Valor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount on Pix %s: %w", pix.EndToEndID, err)
+	}
+
+	for _, refund := range pix.Devolucoes {
+		if refund.Status == StatusRefundFailed {
+			continue
+		}
+		cents, err := ParseAmount(refund.Valor)
+		if err != nil {
+			return 0, fmt.Errorf("invalid refund amount on Pix %s: %w", pix.EndToEndID, err)
+		}
+		gross -= cents
+	}
+
+	if gross < 0 {
+		return 0, nil
+	}
+	return gross, nil
+}
+
+func (r *Refunds) Refund(ctx context.Context, e2eID, key, valor string) (*RefundOutcome, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	pix, err := r.client.PixManagement().GetByE2EID(e2eID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.refundPix(*pix, key, valor)
+}
+
+func (r *Refunds) RefundTxID(ctx context.Context, txid, key string, startDate, endDate time.Time) ([]RefundOutcome, error) {
+	var received []PixDetail
+	for page := 0; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		list, err := r.client.PixManagement().ListReceived(startDate, endDate, &ListReceivedOptions{TxID: txid, PaginaAtual: page, ItensPagina: 100})
+		if err != nil {
+			return nil, err
+		}
+		received = append(received, list.Pix...)
+
+		if page+1 >= list.Parametros.Paginacao.QuantidadeDePaginas {
+			break
+		}
+	}
+
+	var outcomes []RefundOutcome
+	for _, pix := range received {
+		if err := ctx.Err(); err != nil {
+			return outcomes, err
+		}
+
+		remaining, err := RefundableAmount(pix)
+		if err != nil {
+			outcomes = append(outcomes, RefundOutcome{EndToEndID: pix.EndToEndID, Err: err})
+			continue
+		}
+		if remaining == 0 && !hasRefund(pix, RefundIDFor(pix.EndToEndID, key)) {
+			continue
+		}
+
+		outcome, err := r.refundPix(pix, key, "")
+		if err != nil {
+			outcomes = append(outcomes, RefundOutcome{EndToEndID: pix.EndToEndID, Err: err})
+			continue
+		}
+		outcomes = append(outcomes, *outcome)
+	}
+
+	return outcomes, nil
+}
+
+func (r *Refunds) Follow(ctx context.Context, e2eID, refundID string) (*RefundResponse, error) {
+	combinedID := fmt.Sprintf("%s:%s", e2eID, refundID)
+	if _, err := r.client.StatusWatcher().WaitFor(ctx, combinedID, TransactionTypeRefund); err != nil {
+		return nil, err
+	}
+
+	refund, err := r.client.PixManagement().GetRefund(e2eID, refundID)
+	if err != nil {
+		return nil, err
+	}
+
+	if refund.Status == StatusRefundCompleted || refund.Status == StatusRefundFailed {
+		r.release(e2eID, refundID)
+	}
+	return refund, nil
+}
+
+func (r *Refunds) FollowAll(ctx context.Context, outcomes []RefundOutcome) []RefundOutcome {
+	var wg sync.WaitGroup
+	for i := range outcomes {
+		if outcomes[i].Err != nil || outcomes[i].RefundID == "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(outcome *RefundOutcome) {
+			defer wg.Done()
+
+			refund, err := r.Follow(ctx, outcome.EndToEndID, outcome.RefundID)
+			if err != nil {
+				outcome.Err = err
+				return
+			}
+			outcome.Refund = refund
+		}(&outcomes[i])
+	}
+
+	wg.Wait()
+	return outcomes
+}
+
+func (r *Refunds) refundPix(pix PixDetail, key, valor string) (*RefundOutcome, error) {
+	if key == "" {
+		return nil, fmt.Errorf("refund key is required")
+	}
+
+	refundID := RefundIDFor(pix.EndToEndID, key)
+	for _, refund := range pix.Devolucoes {
+		if refund.ID == refundID {
+			r.release(pix.EndToEndID, refundID)
+			existing := RefundResponse(refund)
+			return &RefundOutcome{EndToEndID: pix.EndToEndID, RefundID: refundID, Valor: refund.Valor, Refund: &existing}, nil
+		}
+	}
+
+	remaining, err := RefundableAmount(pix)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	reserved := r.reserved[pix.EndToEndID]
+	for id, cents := range reserved {
+		switch {
+		case hasRefund(pix, id):
+			delete(reserved, id)
+		case id != refundID:
+			remaining -= cents
+		}
+	}
+
+	amount := remaining
+	if valor != "" {
+		amount, err = ParseAmount(valor)
+		if err != nil {
+			r.mu.Unlock()
+			return nil, err
+		}
+	}
+
+	if amount <= 0 || amount > remaining {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("%w: requested %s, refundable %s on Pix %s", ErrRefundExceedsBalance, FormatAmount(amount), FormatAmount(remaining), pix.EndToEndID)
+	}
+
+	if reserved == nil {
+		reserved = make(map[string]int64)
+		r.reserved[pix.EndToEndID] = reserved
+	}
+	reserved[refundID] = amount
+	r.mu.Unlock()
+
+	outcome := &RefundOutcome{EndToEndID: pix.EndToEndID, RefundID: refundID, Valor: FormatAmount(amount)}
+	refund, err := r.client.PixManagement().RequestRefund(pix.EndToEndID, refundID, RefundRequest{Valor: outcome.Valor})
+	if err != nil {
+		r.release(pix.EndToEndID, refundID)
+		return nil, err
+	}
+
+	outcome.Refund = refund
+	return outcome, nil
+}
+
+func (r *Refunds) release(e2eID, refundID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.reserved[e2eID], refundID)
+	if len(r.reserved[e2eID]) == 0 {
+		delete(r.reserved, e2eID)
+	}
+}
+
+func hasRefund(pix PixDetail, refundID string) bool {
+	for _, refund := range pix.Devolucoes {
+		if refund.ID == refundID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package efi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+	"sync"
+	"testing"
+)
+
+func TestRefundableAmount(t *testing.T) {
+	pix := PixDetail{
+		EndToEndID: "E1",
+		Valor:      "100.00",
+		Devolucoes: []DevolucaoRefund{
+			{ID: "r1", Valor: "30.00", Status: StatusRefundCompleted},
+			{ID: "r2", Valor: "20.00", Status: StatusRefundProcessing},
+			{ID: "r3", Valor: "50.00", Status: StatusRefundFailed},
+		},
+	}
+
+	remaining, err := RefundableAmount(pix)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remaining != 5000 {
+		t.Fatalf("expected 50.00 refundable, got %s", FormatAmount(remaining))
+	}
+}
+
+func TestRefundsAreIdempotentPerKey(t *testing.T) {
+	var mu sync.Mutex
+	listed := true
+	puts := 0
+	pix := PixDetail{EndToEndID: "E1", Valor: "100.00"}
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(pix)
+		case http.MethodPut:
+			puts++
+			var request RefundRequest
+			json.NewDecoder(r.Body).Decode(&request)
+
+			refund := DevolucaoRefund{ID: path.Base(r.URL.Path), Valor: request.Valor, Status: StatusRefundProcessing}
+			if listed {
+				pix.Devolucoes = append(pix.Devolucoes, refund)
+			}
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(RefundResponse(refund))
+		}
+	}))
+
+	refunds := client.Refunds()
+	ctx := context.Background()
+
+	first, err := refunds.Refund(ctx, "E1", "order-1-partial", "30.00")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.RefundID != RefundIDFor("E1", "order-1-partial") {
+		t.Fatalf("expected a deterministic refund ID, got %s", first.RefundID)
+	}
+
+	retry, err := refunds.Refund(ctx, "E1", "order-1-partial", "30.00")
+	if err != nil {
+		t.Fatalf("unexpected error on retry: %v", err)
+	}
+	if puts != 1 || retry.RefundID != first.RefundID || retry.Refund.Valor != "30.00" {
+		t.Fatalf("expected the retry to reuse the first refund, got %d requests and %+v", puts, retry)
+	}
+
+	if _, err := refunds.Refund(ctx, "E1", "order-1-rest", "80.00"); !errors.Is(err, ErrRefundExceedsBalance) {
+		t.Fatalf("expected ErrRefundExceedsBalance, got %v", err)
+	}
+
+	mu.Lock()
+	listed = false
+	mu.Unlock()
+
+	if _, err := refunds.Refund(ctx, "E1", "order-1-fee", "40.00"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := refunds.Refund(ctx, "E1", "order-1-rest", "40.00"); !errors.Is(err, ErrRefundExceedsBalance) {
+		t.Fatalf("expected the unlisted refund to stay reserved, got %v", err)
+	}
+	if _, err := refunds.Refund(ctx, "E1", "order-1-rest", "30.00"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if puts != 3 {
+		t.Fatalf("expected 3 refund requests, got %d", puts)
+	}
+}
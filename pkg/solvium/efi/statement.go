@@ -0,0 +1,174 @@
+package efi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type Statements struct {
+	client *Client
+}
+
+func NewStatements(client *Client) *Statements {
+	return &Statements{
+		client: client,
+	}
+}
+
+func (s *Statements) Request(req StatementRequest) (*StatementRequestResponse, error) {
+	bodyBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := s.client.Request(http.MethodPost, "/v1/extrato-cnab/solicitacoes", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to request statement: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to request statement with status %d: %s", resp.StatusCode, body)
+	}
+
+	var requestResp StatementRequestResponse
+	if err := json.NewDecoder(resp.Body).Decode(&requestResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &requestResp, nil
+}
+
+func (s *Statements) GetRequest(id string) (*StatementRequestResponse, error) {
+	resp, err := s.client.Request(http.MethodGet, fmt.Sprintf("/v1/extrato-cnab/solicitacoes/%s", url.PathEscape(id)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statement request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get statement request with status %d: %s", resp.StatusCode, body)
+	}
+
+	var requestResp StatementRequestResponse
+	if err := json.NewDecoder(resp.Body).Decode(&requestResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &requestResp, nil
+}
+
+func (s *Statements) WaitForRequest(ctx context.Context, id string, interval time.Duration) (*StatementRequestResponse, error) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	for {
+		requestResp, err := s.GetRequest(id)
+		if err != nil {
+			return nil, err
+		}
+
+		switch requestResp.Status {
+		case StatementRequestStatusCompleted:
+			return requestResp, nil
+		case StatementRequestStatusFailed:
+			return requestResp, fmt.Errorf("statement request %s failed: %s", id, requestResp.Reason)
+		}
+
+		select {
+		case <-ctx.Done():
+			return requestResp, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (s *Statements) ListFiles() (*StatementFileList, error) {
+	resp, err := s.client.Request(http.MethodGet, "/v1/extrato-cnab/arquivos", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statement files: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to list statement files with status %d: %s", resp.StatusCode, body)
+	}
+
+	var list StatementFileList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &list, nil
+}
+
+func (s *Statements) Download(fileName string) ([]byte, error) {
+	resp, err := s.client.Request(http.MethodGet, fmt.Sprintf("/v1/extrato-cnab/download/%s", url.PathEscape(fileName)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download statement file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to download statement file with status %d: %s", resp.StatusCode, body)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read statement file: %w", err)
+	}
+
+	return data, nil
+}
+
+func (s *Statements) DownloadAndParse(fileName string) (*Statement, error) {
+	data, err := s.Download(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasSuffix(strings.ToLower(fileName), ".ofx") {
+		return ParseOFX(bytes.NewReader(data))
+	}
+	return ParseCNAB240(bytes.NewReader(data))
+}
+
+func JoinReceived(entries []StatementEntry, received []PixDetail) []StatementMatch {
+	byE2E := make(map[string]*PixDetail, len(received))
+	byTxID := make(map[string]*PixDetail, len(received))
+	for i := range received {
+		pix := &received[i]
+		if pix.EndToEndID != "" {
+			byE2E[pix.EndToEndID] = pix
+		}
+		if pix.TxID != "" {
+			byTxID[pix.TxID] = pix
+		}
+	}
+
+	matches := make([]StatementMatch, 0, len(entries))
+	for _, entry := range entries {
+		match := StatementMatch{Entry: entry}
+		if entry.EndToEndID != "" {
+			match.Pix = byE2E[entry.EndToEndID]
+		}
+		if match.Pix == nil && entry.TxID != "" {
+			match.Pix = byTxID[entry.TxID]
+		}
+		matches = append(matches, match)
+	}
+
+	return matches
+}
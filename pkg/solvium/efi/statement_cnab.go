@@ -0,0 +1,104 @@
+package efi
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	endToEndIDPattern = regexp.MustCompile(`\b[ED][0-9]{8}[0-9]{12}[A-Za-z0-9]{11}\b`)
+	txIDPattern       = regexp.MustCompile(`(?i)\btxid[\s:=#-]*([A-Za-z0-9]{26,35})\b`)
+)
+
+func ParseCNAB240(r io.Reader) (*Statement, error) {
+	statement := &Statement{Format: StatementFormatCNAB240}
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if len(line) < 240 {
+			return nil, fmt.Errorf("invalid CNAB 240 line %d: expected 240 characters, got %d", lineNumber, len(line))
+		}
+
+		switch line[7] {
+		case '0':
+			statement.Account = StatementAccount{
+				BankCode: line[0:3],
+				Document: strings.TrimSpace(line[18:32]),
+				Branch:   strings.TrimLeft(line[52:57], "0"),
+				Account:  strings.TrimLeft(line[58:70], "0") + strings.TrimSpace(line[70:71]),
+				Name:     strings.TrimSpace(line[72:102]),
+			}
+		case '3':
+			if line[13] != 'E' {
+				continue
+			}
+			entry, err := parseCNABSegmentE(line, lineNumber)
+			if err != nil {
+				return nil, err
+			}
+			statement.Entries = append(statement.Entries, entry)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read CNAB 240 file: %w", err)
+	}
+
+	return statement, nil
+}
+
+func parseCNABSegmentE(line string, lineNumber int) (StatementEntry, error) {
+	date, err := time.Parse("02012006", line[142:150])
+	if err != nil {
+		return StatementEntry{}, fmt.Errorf("invalid entry date on CNAB 240 line %d: %w", lineNumber, err)
+	}
+
+	cents, err := strconv.ParseInt(line[150:168], 10, 64)
+	if err != nil {
+		return StatementEntry{}, fmt.Errorf("invalid entry amount on CNAB 240 line %d: %w", lineNumber, err)
+	}
+
+	entry := StatementEntry{
+		Date:       date,
+		Valor:      FormatAmount(cents),
+		Type:       StatementEntryType(line[168:169]),
+		Category:   line[169:172],
+		History:    strings.TrimSpace(line[176:201]),
+		Document:   strings.TrimSpace(line[201:240]),
+		Complement: strings.TrimSpace(line[113:133]),
+		Line:       lineNumber,
+	}
+
+	if entry.Type != StatementEntryCredit && entry.Type != StatementEntryDebit {
+		return StatementEntry{}, fmt.Errorf("invalid entry type %q on CNAB 240 line %d", entry.Type, lineNumber)
+	}
+
+	entry.EndToEndID, entry.TxID = extractPixIdentifiers(entry.Document, entry.Complement, entry.History)
+
+	return entry, nil
+}
+
+func extractPixIdentifiers(fields ...string) (endToEndID, txID string) {
+	for _, field := range fields {
+		if endToEndID == "" {
+			endToEndID = endToEndIDPattern.FindString(field)
+		}
+		if txID == "" {
+			if match := txIDPattern.FindStringSubmatch(field); match != nil {
+				txID = match[1]
+			}
+		}
+	}
+	return endToEndID, txID
+}
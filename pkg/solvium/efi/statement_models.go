@@ -0,0 +1,91 @@
+package efi
+
+import "time"
+
+type StatementFormat string
+
+const (
+	StatementFormatCNAB240 StatementFormat = "cnab240"
+	StatementFormatOFX     StatementFormat = "ofx"
+)
+
+type StatementRequestStatus string
+
+const (
+	StatementRequestStatusProcessing StatementRequestStatus = "PROCESSANDO"
+	StatementRequestStatusCompleted  StatementRequestStatus = "CONCLUIDO"
+	StatementRequestStatusFailed     StatementRequestStatus = "FALHA"
+)
+
+type StatementEntryType string
+
+const (
+	StatementEntryCredit StatementEntryType = "C"
+	StatementEntryDebit  StatementEntryType = "D"
+)
+
+type StatementRequest struct {
+	StartDate string          `json:"dataInicio"`
+	EndDate   string          `json:"dataFim"`
+	Format    StatementFormat `json:"formato"`
+}
+
+type StatementRequestResponse struct {
+	ID        string                 `json:"id"`
+	Status    StatementRequestStatus `json:"status"`
+	StartDate string                 `json:"dataInicio"`
+	EndDate   string                 `json:"dataFim"`
+	Format    StatementFormat        `json:"formato"`
+	FileName  string                 `json:"nomeArquivo,omitempty"`
+	CreatedAt string                 `json:"criacao,omitempty"`
+	Reason    string                 `json:"motivo,omitempty"`
+}
+
+type StatementFileInfo struct {
+	FileName  string          `json:"nomeArquivo"`
+	Format    StatementFormat `json:"formato,omitempty"`
+	StartDate string          `json:"dataInicio,omitempty"`
+	EndDate   string          `json:"dataFim,omitempty"`
+	Size      int64           `json:"tamanho,omitempty"`
+	CreatedAt string          `json:"criacao,omitempty"`
+}
+
+type StatementFileList struct {
+	Files []StatementFileInfo `json:"arquivos"`
+}
+
+type StatementAccount struct {
+	BankCode string
+	Branch   string
+	Account  string
+	Document string
+	Name     string
+}
+
+type StatementEntry struct {
+	Date       time.Time
+	Valor      string
+	Type       StatementEntryType
+	Category   string
+	History    string
+	Document   string
+	Complement string
+	EndToEndID string
+	TxID       string
+	Line       int
+}
+
+func (e StatementEntry) IsCredit() bool {
+	return e.Type == StatementEntryCredit
+}
+
+type Statement struct {
+	Format  StatementFormat
+	Account StatementAccount
+	Entries []StatementEntry
+}
+
+type StatementMatch struct {
+	Entry StatementEntry
+	Pix   *PixDetail
+}
@@ -0,0 +1,128 @@
+package efi
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+func ParseOFX(r io.Reader) (*Statement, error) {
+	statement := &Statement{Format: StatementFormatOFX}
+
+	var current *StatementEntry
+	var memo, name, fitID, refNum string
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		tag, value := splitOFXLine(scanner.Text())
+		if tag == "" {
+			continue
+		}
+
+		switch tag {
+		case "BANKID":
+			statement.Account.BankCode = value
+		case "BRANCHID":
+			statement.Account.Branch = value
+		case "ACCTID":
+			statement.Account.Account = value
+		case "STMTTRN":
+			current = &StatementEntry{Line: lineNumber}
+			memo, name, fitID, refNum = "", "", "", ""
+		case "/STMTTRN":
+			if current == nil {
+				return nil, fmt.Errorf("unexpected </STMTTRN> on OFX line %d", lineNumber)
+			}
+			current.History = strings.TrimSpace(strings.TrimSpace(name + " " + memo))
+			current.Document = fitID
+			current.Complement = refNum
+			current.EndToEndID, current.TxID = extractPixIdentifiers(fitID, refNum, memo, name)
+			statement.Entries = append(statement.Entries, *current)
+			current = nil
+		}
+
+		if current == nil {
+			continue
+		}
+
+		switch tag {
+		case "TRNTYPE":
+			current.Category = value
+		case "DTPOSTED":
+			date, err := parseOFXDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid DTPOSTED on OFX line %d: %w", lineNumber, err)
+			}
+			current.Date = date
+		case "TRNAMT":
+			cents, err := ParseAmount(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid TRNAMT on OFX line %d: %w", lineNumber, err)
+			}
+			current.Type = StatementEntryCredit
+			if cents < 0 {
+				current.Type = StatementEntryDebit
+				cents = -cents
+			}
+			current.Valor = FormatAmount(cents)
+		case "FITID":
+			fitID = value
+		case "REFNUM", "CHECKNUM":
+			refNum = value
+		case "MEMO":
+			memo = value
+		case "NAME":
+			name = value
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read OFX file: %w", err)
+	}
+
+	return statement, nil
+}
+
+func splitOFXLine(line string) (tag, value string) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "<") {
+		return "", ""
+	}
+
+	end := strings.IndexByte(line, '>')
+	if end < 0 {
+		return "", ""
+	}
+
+	tag = strings.ToUpper(line[1:end])
+	value = line[end+1:]
+	if i := strings.Index(value, "</"); i >= 0 {
+		value = value[:i]
+	}
+
+	return tag, strings.TrimSpace(value)
+}
+
+func parseOFXDate(value string) (time.Time, error) {
+	if i := strings.IndexByte(value, '['); i >= 0 {
+		value = value[:i]
+	}
+	if i := strings.IndexByte(value, '.'); i >= 0 {
+		value = value[:i]
+	}
+
+	switch len(value) {
+	case 8:
+		return time.Parse("20060102", value)
+	case 12:
+		return time.Parse("200601021504", value)
+	case 14:
+		return time.Parse("20060102150405", value)
+	}
+
+	return time.Time{}, fmt.Errorf("unsupported OFX date %q", value)
+}
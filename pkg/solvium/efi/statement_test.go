@@ -0,0 +1,101 @@
+package efi
+
+import (
+	"strings"
+	"testing"
+)
+
+func cnabLine(fields map[int]string) string {
+	line := []byte(strings.Repeat(" ", 240))
+	for start, value := range fields {
+		copy(line[start-1:], value)
+	}
+	return string(line)
+}
+
+func TestParseCNAB240(t *testing.T) {
+	header := cnabLine(map[int]string{1: "364", 8: "0", 19: "12345678000199", 53: "00001", 59: "000000123456", 71: "7", 73: "ACME LTDA"})
+	entry := cnabLine(map[int]string{
+		1:   "364",
+		8:   "3",
+		14:  "E",
+		114: "PIX RECEBIDO",
+		143: "05032025",
+		151: "000000000000012550",
+		169: "C",
+		170: "101",
+		177: "PIX RECEBIDO",
+		202: "E09089356202503051230abcdefghijk",
+	})
+
+	statement, err := ParseCNAB240(strings.NewReader(header + "\n" + entry + "\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if statement.Account.Account != "1234567" || statement.Account.Branch != "1" {
+		t.Errorf("Unexpected account %+v", statement.Account)
+	}
+	if len(statement.Entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(statement.Entries))
+	}
+
+	got := statement.Entries[0]
+	if got.Valor != "125.50" || !got.IsCredit() {
+		t.Errorf("Expected credit of 125.50, got %s %s", got.Type, got.Valor)
+	}
+	if got.Date.Format("2006-01-02") != "2025-03-05" {
+		t.Errorf("Expected date 2025-03-05, got %s", got.Date.Format("2006-01-02"))
+	}
+	if got.EndToEndID != "E09089356202503051230abcdefghijk" {
+		t.Errorf("Unexpected endToEndId %q", got.EndToEndID)
+	}
+}
+
+func TestParseOFX(t *testing.T) {
+	ofx := `OFXHEADER:100
+<OFX>
+<BANKACCTFROM>
+<BANKID>364
+<BRANCHID>0001
+<ACCTID>1234567
+</BANKACCTFROM>
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20250305123000[-3:BRT]
+<TRNAMT>125.50
+<FITID>E09089356202503051230abcdefghijk
+<MEMO>Pix recebido txid:7978c0c97ea847e78e8849634473c1f1
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20250306
+<TRNAMT>-10.00
+<FITID>123
+<MEMO>Tarifa
+</STMTTRN>
+</BANKTRANLIST>
+</OFX>`
+
+	statement, err := ParseOFX(strings.NewReader(ofx))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(statement.Entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(statement.Entries))
+	}
+
+	credit, debit := statement.Entries[0], statement.Entries[1]
+	if credit.EndToEndID != "E09089356202503051230abcdefghijk" || credit.TxID != "7978c0c97ea847e78e8849634473c1f1" {
+		t.Errorf("Unexpected identifiers %q %q", credit.EndToEndID, credit.TxID)
+	}
+	if debit.Type != StatementEntryDebit || debit.Valor != "10.00" {
+		t.Errorf("Expected debit of 10.00, got %s %s", debit.Type, debit.Valor)
+	}
+
+	matches := JoinReceived(statement.Entries, []PixDetail{{EndToEndID: credit.EndToEndID, Valor: "125.50"}})
+	if matches[0].Pix == nil || matches[1].Pix != nil {
+		t.Errorf("Unexpected join result %+v", matches)
+	}
+}
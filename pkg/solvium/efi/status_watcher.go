@@ -0,0 +1,348 @@
+package efi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type StatusSource string
+
+const (
+	StatusSourcePoll StatusSource = "poll"
+	StatusSourceFeed StatusSource = "feed"
+)
+
+type TransactionRef struct {
+	ID   string
+	Type TransactionType
+}
+
+type StatusChange struct {
+	Ref      TransactionRef
+	Previous string
+	Status   *TransactionStatus
+	Source   StatusSource
+	At       time.Time
+	Err      error
+}
+
+type StatusWatcher struct {
+	client *Client
+
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+	Concurrency     int
+
+	mu        sync.Mutex
+	listeners map[TransactionRef]map[*statusMailbox]struct{}
+}
+
+type statusMailbox struct {
+	mu      sync.Mutex
+	pending map[TransactionRef]*TransactionStatus
+	notify  chan struct{}
+}
+
+func newStatusMailbox() *statusMailbox {
+	return &statusMailbox{
+		pending: make(map[TransactionRef]*TransactionStatus),
+		notify:  make(chan struct{}, 1),
+	}
+}
+
+func (m *statusMailbox) put(ref TransactionRef, status *TransactionStatus) {
+	m.mu.Lock()
+	m.pending[ref] = status
+	m.mu.Unlock()
+
+	select {
+	case m.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (m *statusMailbox) take() []*TransactionStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	statuses := make([]*TransactionStatus, 0, len(m.pending))
+	for ref, status := range m.pending {
+		statuses = append(statuses, status)
+		delete(m.pending, ref)
+	}
+	return statuses
+}
+
+func NewStatusWatcher(client *Client) *StatusWatcher {
+	return &StatusWatcher{
+		client:          client,
+		InitialInterval: time.Second,
+		MaxInterval:     30 * time.Second,
+		Multiplier:      1.5,
+		Concurrency:     4,
+		listeners:       make(map[TransactionRef]map[*statusMailbox]struct{}),
+	}
+}
+
+func (w *StatusWatcher) Feed(id string, txType TransactionType, status string) {
+	fed := &TransactionStatus{ID: id, Type: txType, Status: status}
+	if txType == TransactionTypeRefund {
+		fed.EndToEndID, _, _ = parseRefundID(id)
+	}
+	classifyTransactionStatus(fed)
+	fed.setMessage()
+
+	ref := TransactionRef{ID: id, Type: txType}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for listener := range w.listeners[ref] {
+		listener.put(ref, fed)
+	}
+}
+
+func (w *StatusWatcher) WaitFor(ctx context.Context, id string, txType TransactionType, until ...string) (*TransactionStatus, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var last *TransactionStatus
+	for change := range w.Watch(ctx, TransactionRef{ID: id, Type: txType}) {
+		if change.Err != nil {
+			if IsPermanentStatusError(change.Err) {
+				return last, change.Err
+			}
+			continue
+		}
+
+		last = change.Status
+		for _, status := range until {
+			if last.Status == status {
+				return last, nil
+			}
+		}
+
+		if last.IsCompleted || last.IsFailed {
+			if len(until) == 0 {
+				return last, nil
+			}
+			return last, fmt.Errorf("transaction %s reached terminal status %s", id, last.Status)
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return last, err
+	}
+	return last, fmt.Errorf("stopped watching transaction %s", id)
+}
+
+func (w *StatusWatcher) Watch(ctx context.Context, refs ...TransactionRef) <-chan StatusChange {
+	changes := make(chan StatusChange, len(refs))
+	fed := newStatusMailbox()
+
+	w.subscribe(fed, refs)
+
+	go func() {
+		defer close(changes)
+		defer w.unsubscribe(fed, refs)
+
+		w.run(ctx, refs, fed, changes)
+	}()
+
+	return changes
+}
+
+type watchedTransaction struct {
+	ref      TransactionRef
+	status   string
+	interval time.Duration
+	next     time.Time
+	done     bool
+}
+
+func (w *StatusWatcher) run(ctx context.Context, refs []TransactionRef, fed *statusMailbox, changes chan<- StatusChange) {
+	now := time.Now()
+	watched := make(map[TransactionRef]*watchedTransaction, len(refs))
+	for _, ref := range refs {
+		watched[ref] = &watchedTransaction{ref: ref, interval: w.InitialInterval, next: now}
+	}
+
+	pending := len(watched)
+	emit := func(item *watchedTransaction, status *TransactionStatus, source StatusSource) bool {
+		if item.done {
+			return true
+		}
+
+		if status.Status != item.status {
+			change := StatusChange{Ref: item.ref, Previous: item.status, Status: status, Source: source, At: time.Now()}
+			select {
+			case changes <- change:
+			case <-ctx.Done():
+				return false
+			}
+			item.status = status.Status
+			item.interval = w.InitialInterval
+		} else {
+			item.interval = w.backoff(item.interval)
+		}
+
+		if status.IsCompleted || status.IsFailed {
+			item.done = true
+			pending--
+		}
+		item.next = time.Now().Add(item.interval)
+		return true
+	}
+
+	for pending > 0 {
+		var due []*watchedTransaction
+		var earliest time.Time
+		for _, item := range watched {
+			if item.done {
+				continue
+			}
+			if !item.next.After(time.Now()) {
+				due = append(due, item)
+			} else if earliest.IsZero() || item.next.Before(earliest) {
+				earliest = item.next
+			}
+		}
+
+		if len(due) > 0 {
+			for _, result := range w.poll(due) {
+				item := watched[result.ref]
+				if result.err != nil {
+					select {
+					case changes <- StatusChange{Ref: item.ref, Previous: item.status, Err: result.err, Source: StatusSourcePoll, At: time.Now()}:
+					case <-ctx.Done():
+						return
+					}
+					if IsPermanentStatusError(result.err) {
+						item.done = true
+						pending--
+						continue
+					}
+					item.interval = w.backoff(item.interval)
+					item.next = time.Now().Add(item.interval)
+					continue
+				}
+				if !emit(item, result.status, StatusSourcePoll) {
+					return
+				}
+			}
+			continue
+		}
+
+		timer := time.NewTimer(time.Until(earliest))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-fed.notify:
+			timer.Stop()
+			for _, status := range fed.take() {
+				item, ok := watched[TransactionRef{ID: status.ID, Type: status.Type}]
+				if ok && !emit(item, status, StatusSourceFeed) {
+					return
+				}
+			}
+		case <-timer.C:
+		}
+	}
+}
+
+type polledStatus struct {
+	ref    TransactionRef
+	status *TransactionStatus
+	err    error
+}
+
+func (w *StatusWatcher) poll(due []*watchedTransaction) []polledStatus {
+	concurrency := w.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	results := make([]polledStatus, len(due))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, item := range due {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func(i int, ref TransactionRef) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			status, err := w.client.VerifyStatus(ref.ID, ref.Type)
+			results[i] = polledStatus{ref: ref, status: status, err: err}
+		}(i, item.ref)
+	}
+
+	wg.Wait()
+	return results
+}
+
+func (w *StatusWatcher) backoff(interval time.Duration) time.Duration {
+	multiplier := w.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	next := time.Duration(float64(interval) * multiplier)
+	if w.MaxInterval > 0 && next > w.MaxInterval {
+		next = w.MaxInterval
+	}
+	return next
+}
+
+func IsPermanentStatusError(err error) bool {
+	return errors.Is(err, ErrChargeNotFound) ||
+		errors.Is(err, ErrPixSendNotFound) ||
+		errors.Is(err, ErrRefundNotFound) ||
+		errors.Is(err, ErrInvalidRefundID) ||
+		errors.Is(err, ErrUnsupportedTransactionType)
+}
+
+func (w *StatusWatcher) subscribe(listener *statusMailbox, refs []TransactionRef) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, ref := range refs {
+		if w.listeners[ref] == nil {
+			w.listeners[ref] = make(map[*statusMailbox]struct{})
+		}
+		w.listeners[ref][listener] = struct{}{}
+	}
+}
+
+func (w *StatusWatcher) unsubscribe(listener *statusMailbox, refs []TransactionRef) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, ref := range refs {
+		delete(w.listeners[ref], listener)
+		if len(w.listeners[ref]) == 0 {
+			delete(w.listeners, ref)
+		}
+	}
+}
+
+func classifyTransactionStatus(status *TransactionStatus) {
+	switch status.Type {
+	case TransactionTypeCharge, TransactionTypeDueCharge:
+		status.IsCompleted = status.Status == StatusChargeCompleted
+		status.IsFailed = status.Status == StatusChargeRemovedByUser || status.Status == StatusChargeRemovedByPSP
+	case TransactionTypePixSend:
+		status.IsCompleted = status.Status == StatusPixSendCompleted
+		status.IsFailed = status.Status == StatusPixSendFailed
+	case TransactionTypeRefund:
+		status.IsCompleted = status.Status == StatusRefundCompleted
+		status.IsFailed = status.Status == StatusRefundFailed
+	}
+}
@@ -0,0 +1,81 @@
+package efi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStatusWatcherWaitForReturnsPermanentErrors(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"nome":"cobranca_nao_encontrada"}`, http.StatusNotFound)
+	}))
+
+	watcher := NewStatusWatcher(client)
+	watcher.InitialInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := watcher.WaitFor(ctx, "missing", TransactionTypeCharge); !errors.Is(err, ErrChargeNotFound) {
+		t.Fatalf("expected ErrChargeNotFound, got %v", err)
+	}
+	if _, err := watcher.WaitFor(ctx, "not-a-refund-id", TransactionTypeRefund); !errors.Is(err, ErrInvalidRefundID) {
+		t.Fatalf("expected ErrInvalidRefundID, got %v", err)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("permanent errors should not wait for the context to end")
+	}
+}
+
+func TestStatusWatcherFeedKeepsLatestStatus(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ImmediateChargeResponse{Status: StatusChargeActive})
+	}))
+
+	watcher := NewStatusWatcher(client)
+	watcher.InitialInterval = time.Hour
+	watcher.MaxInterval = time.Hour
+
+	refs := make([]TransactionRef, 5)
+	for i := range refs {
+		refs[i] = TransactionRef{ID: fmt.Sprintf("tx-%d", i), Type: TransactionTypeCharge}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	changes := watcher.Watch(ctx, refs...)
+
+	var wg sync.WaitGroup
+	for _, ref := range refs {
+		wg.Add(1)
+		go func(ref TransactionRef) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				watcher.Feed(ref.ID, ref.Type, fmt.Sprintf("INTERMEDIARIO_%d", i))
+			}
+			watcher.Feed(ref.ID, ref.Type, StatusChargeCompleted)
+		}(ref)
+	}
+	wg.Wait()
+
+	completed := make(map[TransactionRef]bool)
+	for change := range changes {
+		if change.Err != nil {
+			t.Fatalf("unexpected error: %v", change.Err)
+		}
+		if change.Status.IsCompleted {
+			completed[change.Ref] = true
+		}
+	}
+
+	if ctx.Err() != nil || len(completed) != len(refs) {
+		t.Fatalf("expected every transaction to complete, got %v (ctx err %v)", completed, ctx.Err())
+	}
+}
@@ -0,0 +1,458 @@
+package efi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	ErrSubscriptionNotFound         = errors.New("subscription not found")
+	ErrSubscriptionPlanNotFound     = errors.New("subscription plan not found")
+	ErrSubscriptionCustomerNotFound = errors.New("subscription customer not found")
+	ErrSubscriptionInvoiceNotFound  = errors.New("subscription invoice not found")
+	ErrSubscriptionCyclePastDue     = errors.New("subscription cycle is past due")
+)
+
+type SubscriptionStore interface {
+	Subscriptions() ([]Subscription, error)
+	Subscription(id string) (*Subscription, error)
+	Plan(id string) (*SubscriptionPlan, error)
+	Customer(id string) (*SubscriptionCustomer, error)
+	Invoice(subscriptionID string, cycle int) (*SubscriptionInvoice, error)
+	SaveInvoice(invoice *SubscriptionInvoice) error
+}
+
+type MemorySubscriptionStore struct {
+	mu            sync.RWMutex
+	plans         map[string]SubscriptionPlan
+	customers     map[string]SubscriptionCustomer
+	subscriptions map[string]Subscription
+	invoices      map[string]SubscriptionInvoice
+}
+
+func NewMemorySubscriptionStore() *MemorySubscriptionStore {
+	return &MemorySubscriptionStore{
+		plans:         make(map[string]SubscriptionPlan),
+		customers:     make(map[string]SubscriptionCustomer),
+		subscriptions: make(map[string]Subscription),
+		invoices:      make(map[string]SubscriptionInvoice),
+	}
+}
+
+func (s *MemorySubscriptionStore) SavePlan(plan SubscriptionPlan) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.plans[plan.ID] = plan
+}
+
+func (s *MemorySubscriptionStore) SaveCustomer(customer SubscriptionCustomer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.customers[customer.ID] = customer
+}
+
+func (s *MemorySubscriptionStore) SaveSubscription(subscription Subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscriptions[subscription.ID] = subscription
+}
+
+func (s *MemorySubscriptionStore) Subscriptions() ([]Subscription, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	subscriptions := make([]Subscription, 0, len(s.subscriptions))
+	for _, subscription := range s.subscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].ID < subscriptions[j].ID
+	})
+	return subscriptions, nil
+}
+
+func (s *MemorySubscriptionStore) Subscription(id string) (*Subscription, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	subscription, ok := s.subscriptions[id]
+	if !ok {
+		return nil, ErrSubscriptionNotFound
+	}
+	return &subscription, nil
+}
+
+func (s *MemorySubscriptionStore) Plan(id string) (*SubscriptionPlan, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	plan, ok := s.plans[id]
+	if !ok {
+		return nil, ErrSubscriptionPlanNotFound
+	}
+	return &plan, nil
+}
+
+func (s *MemorySubscriptionStore) Customer(id string) (*SubscriptionCustomer, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	customer, ok := s.customers[id]
+	if !ok {
+		return nil, ErrSubscriptionCustomerNotFound
+	}
+	return &customer, nil
+}
+
+func (s *MemorySubscriptionStore) Invoice(subscriptionID string, cycle int) (*SubscriptionInvoice, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	invoice, ok := s.invoices[subscriptionCycleKey(subscriptionID, cycle)]
+	if !ok {
+		return nil, ErrSubscriptionInvoiceNotFound
+	}
+	return &invoice, nil
+}
+
+func (s *MemorySubscriptionStore) SaveInvoice(invoice *SubscriptionInvoice) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.invoices[subscriptionCycleKey(invoice.SubscriptionID, invoice.Cycle)] = *invoice
+	return nil
+}
+
+type SubscriptionBilling struct {
+	client  *Client
+	store   SubscriptionStore
+	intents ChargeIntentStore
+
+	Namespace string
+	LeadDays  int
+}
+
+func NewSubscriptionBilling(client *Client, store SubscriptionStore, intents ChargeIntentStore, namespace string) *SubscriptionBilling {
+	return &SubscriptionBilling{
+		client:    client,
+		store:     store,
+		intents:   intents,
+		Namespace: namespace,
+		LeadDays:  10,
+	}
+}
+
+func (b *SubscriptionBilling) Run(ctx context.Context, now time.Time) (*SubscriptionRunReport, error) {
+	subscriptions, err := b.store.Subscriptions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load subscriptions: %w", err)
+	}
+
+	report := &SubscriptionRunReport{}
+	today := subscriptionDay(now)
+
+	for _, subscription := range subscriptions {
+		if subscription.Status == SubscriptionPaused {
+			continue
+		}
+		if subscription.Status == SubscriptionCanceled && subscription.EndDate.IsZero() {
+			continue
+		}
+
+		for cycle := 0; ; cycle++ {
+			if err := ctx.Err(); err != nil {
+				return report, err
+			}
+
+			start, _ := SubscriptionPeriod(subscription, cycle)
+			if !subscription.EndDate.IsZero() && !start.Before(subscriptionDay(subscription.EndDate)) {
+				break
+			}
+			if start.AddDate(0, 0, -b.LeadDays).After(today) {
+				break
+			}
+
+			invoice, issued, err := b.issueCycle(ctx, subscription.ID, cycle, now)
+			switch {
+			case err != nil:
+				report.Errors = append(report.Errors, SubscriptionRunError{SubscriptionID: subscription.ID, Cycle: cycle, Err: err})
+			case !issued:
+				report.Skipped++
+			case invoice.Status == SubscriptionInvoiceWaived:
+				report.Waived = append(report.Waived, *invoice)
+			default:
+				report.Issued = append(report.Issued, *invoice)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func (b *SubscriptionBilling) IssueCycle(ctx context.Context, subscriptionID string, cycle int) (*SubscriptionInvoice, bool, error) {
+	return b.issueCycle(ctx, subscriptionID, cycle, time.Now())
+}
+
+func (b *SubscriptionBilling) issueCycle(ctx context.Context, subscriptionID string, cycle int, now time.Time) (*SubscriptionInvoice, bool, error) {
+	subscription, err := b.store.Subscription(subscriptionID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	invoice, err := b.store.Invoice(subscriptionID, cycle)
+	switch {
+	case err == nil:
+		if invoice.Status != SubscriptionInvoicePending {
+			return invoice, false, nil
+		}
+	case errors.Is(err, ErrSubscriptionInvoiceNotFound):
+		invoice, err = b.invoice(subscription, cycle)
+		if err != nil {
+			return nil, false, err
+		}
+	default:
+		return nil, false, fmt.Errorf("failed to load subscription invoice: %w", err)
+	}
+
+	if invoice.Charged == 0 {
+		invoice.Status = SubscriptionInvoiceWaived
+		invoice.IssuedAt = now
+		if err := b.store.SaveInvoice(invoice); err != nil {
+			return nil, false, fmt.Errorf("failed to save subscription invoice: %w", err)
+		}
+		return invoice, true, nil
+	}
+
+	if invoice.DueDate < subscriptionDay(now).Format("2006-01-02") {
+		return nil, false, fmt.Errorf("%w: cycle %d of %s was due on %s", ErrSubscriptionCyclePastDue, cycle, subscriptionID, invoice.DueDate)
+	}
+
+	if err := b.store.SaveInvoice(invoice); err != nil {
+		return nil, false, fmt.Errorf("failed to save subscription invoice: %w", err)
+	}
+
+	plan, err := b.store.Plan(invoice.PlanID)
+	if err != nil {
+		return nil, false, err
+	}
+	customer, err := b.store.Customer(subscription.CustomerID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	charges := NewIdempotentCharges(b.client, b.intents, b.Namespace)
+	result, err := charges.CreateDue(ctx, subscriptionCycleKey(subscriptionID, cycle), subscriptionDueCharge(invoice, plan, customer))
+	if err != nil {
+		return nil, false, err
+	}
+
+	invoice.TxID = result.Intent.TxID
+	invoice.Status = SubscriptionInvoiceIssued
+	invoice.IssuedAt = now
+	if err := b.store.SaveInvoice(invoice); err != nil {
+		return nil, false, fmt.Errorf("failed to save subscription invoice: %w", err)
+	}
+
+	return invoice, true, nil
+}
+
+func (b *SubscriptionBilling) Preview(subscriptionID string, cycle int) (*SubscriptionInvoice, error) {
+	subscription, err := b.store.Subscription(subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	return b.invoice(subscription, cycle)
+}
+
+func (b *SubscriptionBilling) invoice(subscription *Subscription, cycle int) (*SubscriptionInvoice, error) {
+	amount, planID, err := ProrateSubscriptionCycle(*subscription, cycle, b.planAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	var adjustment int64
+	if cycle > 0 {
+		previous, err := b.store.Invoice(subscription.ID, cycle-1)
+		switch {
+		case err == nil:
+			recomputed, _, err := ProrateSubscriptionCycle(*subscription, cycle-1, b.planAmount)
+			if err != nil {
+				return nil, err
+			}
+			adjustment = recomputed - previous.Amount - previous.Credit
+		case !errors.Is(err, ErrSubscriptionInvoiceNotFound):
+			return nil, fmt.Errorf("failed to load subscription invoice: %w", err)
+		}
+	}
+
+	start, end := SubscriptionPeriod(*subscription, cycle)
+	dueDate, err := b.client.adjustScheduledDate(start.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	invoice := &SubscriptionInvoice{
+		SubscriptionID: subscription.ID,
+		Cycle:          cycle,
+		TxID:           TxIDForOrder(b.Namespace, subscriptionCycleKey(subscription.ID, cycle)),
+		PlanID:         planID,
+		PeriodStart:    start,
+		PeriodEnd:      end,
+		DueDate:        dueDate,
+		Amount:         amount,
+		Adjustment:     adjustment,
+		Charged:        amount + adjustment,
+		Status:         SubscriptionInvoicePending,
+	}
+	if invoice.Charged <= 0 {
+		invoice.Credit = -invoice.Charged
+		invoice.Charged = 0
+	}
+
+	return invoice, nil
+}
+
+func (b *SubscriptionBilling) planAmount(id string) (int64, error) {
+	plan, err := b.store.Plan(id)
+	if err != nil {
+		return 0, err
+	}
+
+	amount, err := ParseAmount(plan.Amount)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount for plan %s: %w", id, err)
+	}
+	return amount, nil
+}
+
+func SubscriptionPeriod(subscription Subscription, cycle int) (time.Time, time.Time) {
+	interval := subscription.IntervalMonths
+	if interval <= 0 {
+		interval = 1
+	}
+
+	anchor := subscriptionDay(subscription.StartDate)
+	return addSubscriptionMonths(anchor, cycle*interval), addSubscriptionMonths(anchor, (cycle+1)*interval)
+}
+
+func ProrateSubscriptionCycle(subscription Subscription, cycle int, planAmount func(planID string) (int64, error)) (int64, string, error) {
+	start, end := SubscriptionPeriod(subscription, cycle)
+	periodDays := subscriptionDaysBetween(start, end)
+
+	changes := append([]SubscriptionPlanChange(nil), subscription.PlanChanges...)
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].EffectiveAt.Before(changes[j].EffectiveAt)
+	})
+
+	planAt := func(t time.Time) string {
+		planID := subscription.PlanID
+		for _, change := range changes {
+			if subscriptionDay(change.EffectiveAt).After(t) {
+				break
+			}
+			planID = change.PlanID
+		}
+		return planID
+	}
+
+	planID := planAt(start)
+
+	billedEnd := end
+	if !subscription.EndDate.IsZero() {
+		if last := subscriptionDay(subscription.EndDate); last.Before(billedEnd) {
+			billedEnd = last
+		}
+	}
+	if !billedEnd.After(start) {
+		return 0, planID, nil
+	}
+
+	boundaries := []time.Time{start}
+	for _, change := range changes {
+		at := subscriptionDay(change.EffectiveAt)
+		if at.After(start) && at.Before(billedEnd) {
+			boundaries = append(boundaries, at)
+		}
+	}
+	boundaries = append(boundaries, billedEnd)
+
+	var weighted int64
+	for i := 0; i+1 < len(boundaries); i++ {
+		days := subscriptionDaysBetween(boundaries[i], boundaries[i+1])
+		if days == 0 {
+			continue
+		}
+
+		amount, err := planAmount(planAt(boundaries[i]))
+		if err != nil {
+			return 0, planID, err
+		}
+		weighted += amount * int64(days)
+	}
+
+	return (weighted + int64(periodDays)/2) / int64(periodDays), planID, nil
+}
+
+func subscriptionDueCharge(invoice *SubscriptionInvoice, plan *SubscriptionPlan, customer *SubscriptionCustomer) CreateDueChargeRequest {
+	desconto := plan.Desconto
+	desconto.DescontoDataFixa = append([]DescontoDataFixa(nil), plan.Desconto.DescontoDataFixa...)
+	if plan.DiscountDaysBeforeDue > 0 && (desconto.Modalidade == 1 || desconto.Modalidade == 2) && len(desconto.DescontoDataFixa) == 0 {
+		if due, err := time.Parse("2006-01-02", invoice.DueDate); err == nil {
+			desconto.DescontoDataFixa = []DescontoDataFixa{{
+				Data:      due.AddDate(0, 0, -plan.DiscountDaysBeforeDue).Format("2006-01-02"),
+				ValorPerc: desconto.ValorPerc,
+			}}
+			desconto.ValorPerc = ""
+		}
+	}
+
+	info := append([]InfoAdicional(nil), plan.InfoAdicionais...)
+	info = append(info,
+		InfoAdicional{Nome: "Assinatura", Valor: invoice.SubscriptionID},
+		InfoAdicional{Nome: "Periodo", Valor: invoice.PeriodStart.Format("02/01/2006") + " a " + invoice.PeriodEnd.AddDate(0, 0, -1).Format("02/01/2006")},
+	)
+
+	return CreateDueChargeRequest{
+		Calendario: CalendarioDueCharge{
+			DataDeVencimento:       invoice.DueDate,
+			ValidadeAposVencimento: plan.ValidadeAposVencimento,
+		},
+		Devedor: customer.Devedor,
+		Valor: ValorDueCharge{
+			Original: FormatAmount(invoice.Charged),
+			Multa:    plan.Multa,
+			Juros:    plan.Juros,
+			Desconto: desconto,
+		},
+		Chave:              plan.Chave,
+		SolicitacaoPagador: plan.SolicitacaoPagador,
+		InfoAdicionais:     info,
+	}
+}
+
+func subscriptionCycleKey(subscriptionID string, cycle int) string {
+	return subscriptionID + ":" + strconv.Itoa(cycle)
+}
+
+func subscriptionDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func subscriptionDaysBetween(start, end time.Time) int {
+	return int(end.Sub(start).Hours() / 24)
+}
+
+func addSubscriptionMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,83 @@
+package efi
+
+import "time"
+
+type SubscriptionStatus string
+
+const (
+	SubscriptionActive   SubscriptionStatus = "active"
+	SubscriptionPaused   SubscriptionStatus = "paused"
+	SubscriptionCanceled SubscriptionStatus = "canceled"
+)
+
+type SubscriptionInvoiceStatus string
+
+const (
+	SubscriptionInvoicePending SubscriptionInvoiceStatus = "pending"
+	SubscriptionInvoiceIssued  SubscriptionInvoiceStatus = "issued"
+	SubscriptionInvoiceWaived  SubscriptionInvoiceStatus = "waived"
+)
+
+type SubscriptionPlan struct {
+	ID                     string
+	Name                   string
+	Amount                 string
+	Chave                  string
+	SolicitacaoPagador     string
+	ValidadeAposVencimento int
+	Multa                  Multa
+	Juros                  Juros
+	Desconto               Desconto
+	DiscountDaysBeforeDue  int
+	InfoAdicionais         []InfoAdicional
+}
+
+type SubscriptionCustomer struct {
+	ID      string
+	Devedor DevedorDueCharge
+}
+
+type SubscriptionPlanChange struct {
+	EffectiveAt time.Time
+	PlanID      string
+}
+
+type Subscription struct {
+	ID             string
+	CustomerID     string
+	PlanID         string
+	Status         SubscriptionStatus
+	StartDate      time.Time
+	EndDate        time.Time
+	IntervalMonths int
+	PlanChanges    []SubscriptionPlanChange
+}
+
+type SubscriptionInvoice struct {
+	SubscriptionID string
+	Cycle          int
+	TxID           string
+	PlanID         string
+	PeriodStart    time.Time
+	PeriodEnd      time.Time
+	DueDate        string
+	Amount         int64
+	Adjustment     int64
+	Charged        int64
+	Credit         int64
+	Status         SubscriptionInvoiceStatus
+	IssuedAt       time.Time
+}
+
+type SubscriptionRunError struct {
+	SubscriptionID string
+	Cycle          int
+	Err            error
+}
+
+type SubscriptionRunReport struct {
+	Issued  []SubscriptionInvoice
+	Waived  []SubscriptionInvoice
+	Skipped int
+	Errors  []SubscriptionRunError
+}
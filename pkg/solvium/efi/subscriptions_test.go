@@ -0,0 +1,140 @@
+package efi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestProrateSubscriptionCycle(t *testing.T) {
+	amounts := map[string]int64{"basic": 3000, "pro": 6000}
+	planAmount := func(id string) (int64, error) {
+		return amounts[id], nil
+	}
+
+	subscription := Subscription{
+		ID:        "sub-1",
+		PlanID:    "basic",
+		StartDate: time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC),
+		PlanChanges: []SubscriptionPlanChange{
+			{EffectiveAt: time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC), PlanID: "pro"},
+		},
+	}
+
+	start, end := SubscriptionPeriod(subscription, 1)
+	if start.Format("2006-01-02") != "2024-02-29" || end.Format("2006-01-02") != "2024-03-31" {
+		t.Fatalf("unexpected period %s to %s", start, end)
+	}
+
+	amount, planID, err := ProrateSubscriptionCycle(subscription, 0, planAmount)
+	if err != nil || amount != 3000 || planID != "basic" {
+		t.Fatalf("cycle 0: got %d %s %v", amount, planID, err)
+	}
+
+	amount, _, err = ProrateSubscriptionCycle(subscription, 1, planAmount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := int64((3000*15 + 6000*16 + 15) / 31); amount != want {
+		t.Fatalf("cycle 1: got %d, want %d", amount, want)
+	}
+
+	subscription.EndDate = time.Date(2024, time.April, 15, 0, 0, 0, 0, time.UTC)
+	amount, planID, err = ProrateSubscriptionCycle(subscription, 2, planAmount)
+	if err != nil || planID != "pro" {
+		t.Fatal(err, planID)
+	}
+	if want := int64((6000*15 + 15) / 30); amount != want {
+		t.Fatalf("cycle 2: got %d, want %d", amount, want)
+	}
+}
+
+func TestSubscriptionBillingRun(t *testing.T) {
+	var mu sync.Mutex
+	charges := make(map[string]CreateDueChargeRequest)
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		txid := path.Base(r.URL.Path)
+		if r.Method != http.MethodPut {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+
+		var request CreateDueChargeRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		charges[txid] = request
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(DueChargeResponse{TxID: txid, Status: StatusChargeActive})
+	}))
+
+	store := NewMemorySubscriptionStore()
+	store.SavePlan(SubscriptionPlan{ID: "basic", Amount: "30.00", Chave: "pix@example.com"})
+	store.SaveCustomer(SubscriptionCustomer{ID: "customer-1", Devedor: DevedorDueCharge{Nome: "Ana"}})
+
+	today := subscriptionDay(time.Now())
+	store.SaveSubscription(Subscription{
+		ID:         "sub-1",
+		CustomerID: "customer-1",
+		PlanID:     "basic",
+		Status:     SubscriptionActive,
+		StartDate:  addSubscriptionMonths(today.AddDate(0, 0, 5), -2),
+	})
+
+	intents := NewMemoryChargeIntentStore()
+	billing := NewSubscriptionBilling(client, store, intents, "subscriptions")
+
+	report, err := billing.Run(context.Background(), time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Issued) != 1 || report.Issued[0].Cycle != 2 {
+		t.Fatalf("expected only the upcoming cycle to be issued, got %+v", report)
+	}
+	if len(report.Errors) != 2 {
+		t.Fatalf("expected the two past cycles to be reported, got %+v", report.Errors)
+	}
+	for i, runErr := range report.Errors {
+		if runErr.Cycle != i || !errors.Is(runErr.Err, ErrSubscriptionCyclePastDue) {
+			t.Fatalf("expected cycle %d to be reported past due, got %+v", i, runErr)
+		}
+	}
+
+	issued := report.Issued[0]
+	if charge, ok := charges[issued.TxID]; !ok || charge.Calendario.DataDeVencimento != issued.DueDate || charge.Valor.Original != "30.00" {
+		t.Fatalf("unexpected charges: %+v", charges)
+	}
+	if intent, err := intents.Get("subscriptions", "sub-1:2"); err != nil || intent.Status != ChargeIntentCreated {
+		t.Fatalf("expected the charge intent to be persisted, got %+v %v", intent, err)
+	}
+
+	report, err = billing.Run(context.Background(), time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Issued) != 0 || report.Skipped != 1 || len(report.Errors) != 2 || len(charges) != 1 {
+		t.Fatalf("expected the rerun to skip the issued cycle, got %+v", report)
+	}
+
+	if _, _, err := billing.IssueCycle(context.Background(), "sub-1", 0); !errors.Is(err, ErrSubscriptionCyclePastDue) {
+		t.Fatalf("expected ErrSubscriptionCyclePastDue, got %v", err)
+	}
+	if len(charges) != 1 {
+		t.Fatalf("expected no charge for a past cycle, got %d", len(charges))
+	}
+
+	report, err = billing.Run(context.Background(), addSubscriptionMonths(today, -2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Errors) != 0 || len(report.Issued) != 1 || report.Issued[0].Cycle != 0 {
+		t.Fatalf("expected a run on an earlier clock to issue cycle 0, got %+v", report)
+	}
+}
@@ -1,9 +1,16 @@
 package efi
 
 import (
+	"errors"
 	"fmt"
 )
 
+var (
+	ErrPixSendNotFound            = errors.New("sent Pix not found")
+	ErrRefundNotFound             = errors.New("refund not found")
+	ErrInvalidRefundID            = errors.New("invalid refund ID format, expected 'e2eID:refundID'")
+	ErrUnsupportedTransactionType = errors.New("unsupported transaction type")
+)
 
 type TransactionType string
 
@@ -39,6 +46,7 @@ type TransactionStatus struct {
 	IsCompleted bool            
 	IsFailed    bool            
 	Message     string          
+	EndToEndID  string          
 }
 
 
@@ -51,6 +59,9 @@ func (c *Client) verifyChargeStatus(status *TransactionStatus) error {
 	status.Status = charge.Status
 	status.IsCompleted = charge.Status == StatusChargeCompleted
 	status.IsFailed = charge.Status == StatusChargeRemovedByUser || charge.Status == StatusChargeRemovedByPSP
+	if len(charge.Pix) > 0 {
+		status.EndToEndID = charge.Pix[0].EndToEndID
+	}
 
 	return nil
 }
@@ -65,6 +76,9 @@ func (c *Client) verifyDueChargeStatus(status *TransactionStatus) error {
 	status.Status = charge.Status
 	status.IsCompleted = charge.Status == StatusChargeCompleted
 	status.IsFailed = charge.Status == StatusChargeRemovedByUser || charge.Status == StatusChargeRemovedByPSP
+	if len(charge.Pix) > 0 {
+		status.EndToEndID = charge.Pix[0].EndToEndID
+	}
 
 	return nil
 }
@@ -94,7 +108,7 @@ func (c *Client) verifyRefundStatus(status *TransactionStatus) error {
 	
 	e2eID, refundID, ok := parseRefundID(status.ID)
 	if !ok {
-		return fmt.Errorf("invalid refund ID format, expected 'e2eID:refundID'")
+		return ErrInvalidRefundID
 	}
 
 	refund, err := c.PixManagement().GetRefund(e2eID, refundID)
@@ -103,6 +117,7 @@ func (c *Client) verifyRefundStatus(status *TransactionStatus) error {
 	}
 
 	status.Status = refund.Status
+	status.EndToEndID = e2eID
 	status.IsCompleted = refund.Status == StatusRefundCompleted
 	status.IsFailed = refund.Status == StatusRefundFailed
 
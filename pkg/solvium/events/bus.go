@@ -0,0 +1,204 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+)
+
+var (
+	ErrBusClosed = errors.New("event bus is closed")
+	ErrBusFull   = errors.New("event bus shard is full")
+)
+
+type workerKey struct{}
+
+type Handler func(ctx context.Context, event Event) error
+
+type subscription struct {
+	id      int
+	handler Handler
+}
+
+type Bus struct {
+	OnError func(event Event, err error)
+
+	mu       sync.RWMutex
+	handlers map[Type][]subscription
+	nextID   int
+
+	sendMu  sync.Mutex
+	sending sync.WaitGroup
+	shards  []chan Event
+	closed  bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewBus(workers int) *Bus {
+	if workers <= 0 {
+		workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bus := &Bus{
+		handlers: make(map[Type][]subscription),
+		shards:   make([]chan Event, workers),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+
+	for i := range bus.shards {
+		bus.shards[i] = make(chan Event, 64)
+		bus.wg.Add(1)
+		go bus.worker(bus.shards[i])
+	}
+
+	return bus
+}
+
+func Subscribe[E Event](bus *Bus, handler func(ctx context.Context, event E) error) func() {
+	var zero E
+	return bus.subscribe(zero.Type(), func(ctx context.Context, event Event) error {
+		typed, ok := event.(E)
+		if !ok {
+			return nil
+		}
+		return handler(ctx, typed)
+	})
+}
+
+func (b *Bus) SubscribeAll(handler Handler) func() {
+	return b.subscribe("", handler)
+}
+
+func (b *Bus) subscribe(eventType Type, handler Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.handlers[eventType] = append(b.handlers[eventType], subscription{id: id, handler: handler})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		subscriptions := b.handlers[eventType]
+		for i, sub := range subscriptions {
+			if sub.id == id {
+				b.handlers[eventType] = append(subscriptions[:i:i], subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish blocks while a shard is full, except when called with the context a
+// handler received: a worker waiting on its own queue would never drain it, so
+// those publishes fail with ErrBusFull instead.
+func (b *Bus) Publish(ctx context.Context, events ...Event) error {
+	b.sendMu.Lock()
+	if b.closed {
+		b.sendMu.Unlock()
+		return ErrBusClosed
+	}
+	b.sending.Add(1)
+	b.sendMu.Unlock()
+	defer b.sending.Done()
+
+	reentrant := ctx.Value(workerKey{}) == b
+	for _, event := range events {
+		shard := b.shards[b.shard(event.ResourceID())]
+		if reentrant {
+			select {
+			case shard <- event:
+			default:
+				return fmt.Errorf("%w: cannot queue %s for %s from a handler", ErrBusFull, event.Type(), event.ResourceID())
+			}
+			continue
+		}
+
+		select {
+		case shard <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func (b *Bus) Dispatch(ctx context.Context, event Event) error {
+	var errs []error
+	for _, handler := range b.subscribers(event.Type()) {
+		if err := b.call(ctx, handler, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (b *Bus) Close() {
+	b.sendMu.Lock()
+	if b.closed {
+		b.sendMu.Unlock()
+		return
+	}
+	b.closed = true
+	b.sendMu.Unlock()
+
+	b.sending.Wait()
+	for _, shard := range b.shards {
+		close(shard)
+	}
+
+	b.wg.Wait()
+	b.cancel()
+}
+
+func (b *Bus) worker(events <-chan Event) {
+	defer b.wg.Done()
+
+	ctx := context.WithValue(b.ctx, workerKey{}, b)
+	for event := range events {
+		for _, handler := range b.subscribers(event.Type()) {
+			if err := b.call(ctx, handler, event); err != nil && b.OnError != nil {
+				b.OnError(event, err)
+			}
+		}
+	}
+}
+
+func (b *Bus) subscribers(eventType Type) []Handler {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	handlers := make([]Handler, 0, len(b.handlers[eventType])+len(b.handlers[""]))
+	for _, sub := range b.handlers[eventType] {
+		handlers = append(handlers, sub.handler)
+	}
+	for _, sub := range b.handlers[""] {
+		handlers = append(handlers, sub.handler)
+	}
+	return handlers
+}
+
+func (b *Bus) call(ctx context.Context, handler Handler, event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("event handler panicked: %v", r)
+		}
+	}()
+	return handler(ctx, event)
+}
+
+func (b *Bus) shard(resourceID string) int {
+	hash := fnv.New32a()
+	hash.Write([]byte(resourceID))
+	return int(hash.Sum32() % uint32(len(b.shards)))
+}
@@ -0,0 +1,185 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/solviumdream/solviumpayments/pkg/solvium/efi"
+)
+
+func TestBusTypedSubscriptionsKeepResourceOrder(t *testing.T) {
+	bus := NewBus(4)
+
+	var mu sync.Mutex
+	received := make(map[string][]string)
+	var failed int
+
+	Subscribe(bus, func(ctx context.Context, event ChargePaid) error {
+		mu.Lock()
+		defer mu.Unlock()
+		received[event.ResourceID()] = append(received[event.ResourceID()], event.EndToEndID)
+		return nil
+	})
+	Subscribe(bus, func(ctx context.Context, event PixSendFailed) error {
+		mu.Lock()
+		defer mu.Unlock()
+		failed++
+		return nil
+	})
+
+	for i := 0; i < 50; i++ {
+		for _, resource := range []string{"charge:a", "charge:b", "charge:c"} {
+			bus.Publish(context.Background(), ChargePaid{Metadata: Metadata{Resource: resource}, EndToEndID: string(rune('A' + i))})
+		}
+	}
+	bus.Publish(context.Background(), PixSent{Metadata: Metadata{Resource: "pix_send:x"}})
+	bus.Close()
+
+	for resource, ids := range received {
+		if len(ids) != 50 {
+			t.Fatalf("%s: got %d events", resource, len(ids))
+		}
+		for i, id := range ids {
+			if id != string(rune('A'+i)) {
+				t.Fatalf("%s: event %d out of order", resource, i)
+			}
+		}
+	}
+	if failed != 0 {
+		t.Fatalf("PixSendFailed handler received %d events", failed)
+	}
+	if err := bus.Publish(context.Background(), PixSent{}); err != ErrBusClosed {
+		t.Fatalf("expected ErrBusClosed, got %v", err)
+	}
+}
+
+func TestPixWebhookEvents(t *testing.T) {
+	callback, err := efi.ParsePixWebhookCallback([]byte(`{"pix":[
+		{"endToEndId":"E1","txid":"tx1","valor":"10.50","horario":"2024-05-01T10:00:00Z"},
+		{"endToEndId":"E2","valor":"5.00","tipo":"SOLICITACAO","status":"REALIZADO","gnExtras":{"idEnvio":"env1"}},
+		{"endToEndId":"E3","txid":"tx3","valor":"7.00","devolucoes":[{"id":"d1","valor":"7.00","status":"DEVOLVIDO"}]}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := PixWebhookEvents(callback)
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(events))
+	}
+
+	paid, ok := events[0].(ChargePaid)
+	if !ok || paid.TxID != "tx1" || paid.Amount != 1050 || paid.ResourceID() != "pix:E1" {
+		t.Fatalf("unexpected charge event %+v", events[0])
+	}
+	if sent, ok := events[1].(PixSent); !ok || sent.IDEnvio != "env1" {
+		t.Fatalf("unexpected send event %+v", events[1])
+	}
+	if refund, ok := events[2].(RefundCompleted); !ok || refund.RefundID != "d1" || refund.Amount != 700 {
+		t.Fatalf("unexpected refund event %+v", events[2])
+	}
+}
+
+func TestChargeEventsShareOneResourceAcrossAdapters(t *testing.T) {
+	paid, _ := efi.ParsePixWebhookCallback([]byte(`{"pix":[{"endToEndId":"E9","txid":"tx9","valor":"10.00"},{"endToEndId":"E8","valor":"4.00"}]}`))
+	refunded, _ := efi.ParsePixWebhookCallback([]byte(`{"pix":[{"endToEndId":"E9","txid":"tx9","valor":"10.00","devolucoes":[{"id":"d1","valor":"10.00","status":"DEVOLVIDO"}]}]}`))
+
+	sequence := PixWebhookEvents(paid)
+	sequence = append(sequence, StatusEvents(&efi.TransactionStatus{ID: "tx9", Type: efi.TransactionTypeCharge, Status: efi.StatusChargeCompleted, EndToEndID: "E9"})...)
+	sequence = append(sequence, PixWebhookEvents(refunded)...)
+	sequence = append(sequence, StatusEvents(&efi.TransactionStatus{ID: "E9:d1", Type: efi.TransactionTypeRefund, Status: efi.StatusRefundCompleted})...)
+	sequence = append(sequence, StatusEvents(&efi.TransactionStatus{ID: "E8:d2", Type: efi.TransactionTypeRefund, Status: efi.StatusRefundCompleted})...)
+	sequence = append(sequence, StatusEvents(&efi.TransactionStatus{ID: "tx7", Type: efi.TransactionTypeCharge, Status: efi.StatusChargeRemovedByPSP})...)
+
+	expected := map[string][]Type{
+		"pix:E9":     {TypeChargePaid, TypeChargePaid, TypeRefundCompleted, TypeRefundCompleted},
+		"pix:E8":     {TypeChargePaid, TypeRefundCompleted},
+		"charge:tx7": {TypeChargeExpired},
+	}
+
+	bus := NewBus(8)
+
+	var mu sync.Mutex
+	received := make(map[string][]Type)
+	bus.SubscribeAll(func(ctx context.Context, event Event) error {
+		if event.Type() == TypeChargePaid {
+			time.Sleep(10 * time.Millisecond)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		received[event.ResourceID()] = append(received[event.ResourceID()], event.Type())
+		return nil
+	})
+
+	if err := bus.Publish(context.Background(), sequence...); err != nil {
+		t.Fatal(err)
+	}
+	bus.Close()
+
+	if len(received) != len(expected) {
+		t.Fatalf("expected resources %v, got %v", expected, received)
+	}
+	for resource, types := range expected {
+		got := received[resource]
+		if len(got) != len(types) {
+			t.Fatalf("%s: expected %v, got %v", resource, types, got)
+		}
+		for i := range types {
+			if got[i] != types[i] {
+				t.Fatalf("%s: expected %v, got %v", resource, types, got)
+			}
+		}
+	}
+}
+
+func TestUnsettledBillPaymentIsNotFailed(t *testing.T) {
+	callback := &efi.BillPaymentWebhookCallback{Identifier: "bill-1"}
+	callback.Status.Current = string(efi.BillPaymentStatusUnsettled)
+
+	if events := BillPaymentWebhookEvents(callback); len(events) != 0 {
+		t.Fatalf("expected no events for a scheduled bill, got %v", events)
+	}
+}
+
+func TestBusReentrantPublishDoesNotDeadlock(t *testing.T) {
+	bus := NewBus(1)
+
+	var full int32
+	handled := make(chan struct{})
+	Subscribe(bus, func(ctx context.Context, event ChargePaid) error {
+		defer close(handled)
+		for i := 0; i < 200; i++ {
+			err := bus.Publish(ctx, PixSent{Metadata: Metadata{Resource: event.ResourceID()}})
+			if errors.Is(err, ErrBusFull) {
+				atomic.AddInt32(&full, 1)
+			} else if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err := bus.Publish(context.Background(), ChargePaid{Metadata: Metadata{Resource: "pix:E1"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		<-handled
+		bus.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("bus deadlocked on a re-entrant publish")
+	}
+	if atomic.LoadInt32(&full) == 0 {
+		t.Fatal("expected re-entrant publishes beyond the shard buffer to fail with ErrBusFull")
+	}
+}
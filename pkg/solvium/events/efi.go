@@ -0,0 +1,270 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/solviumdream/solviumpayments/pkg/solvium/efi"
+)
+
+func PixWebhookEvents(callback *efi.PixWebhookCallback) []Event {
+	var events []Event
+
+	for _, pix := range callback.Pix {
+		at := parseTime(pix.Horario)
+
+		if pix.IsSend() {
+			idEnvio := ""
+			if pix.GnExtras != nil {
+				idEnvio = pix.GnExtras.IDEnvio
+			}
+			meta := Metadata{Source: SourcePixWebhook, Resource: "pix_send:" + firstNonEmpty(idEnvio, pix.EndToEndID), OccurredAt: at}
+
+			switch pix.Status {
+			case efi.StatusPixSendCompleted:
+				meta.ID = string(TypePixSent) + ":" + pix.EndToEndID
+				events = append(events, PixSent{Metadata: meta, IDEnvio: idEnvio, EndToEndID: pix.EndToEndID, Amount: amount(pix.Valor)})
+			case efi.StatusPixSendFailed:
+				reason := ""
+				if pix.GnExtras != nil && pix.GnExtras.Erro != nil {
+					reason = pix.GnExtras.Erro.Motivo
+				}
+				meta.ID = string(TypePixSendFailed) + ":" + firstNonEmpty(pix.EndToEndID, idEnvio)
+				events = append(events, PixSendFailed{Metadata: meta, IDEnvio: idEnvio, EndToEndID: pix.EndToEndID, Reason: reason})
+			}
+		} else if len(pix.Devolucoes) == 0 {
+			events = append(events, ChargePaid{
+				Metadata:   Metadata{ID: string(TypeChargePaid) + ":" + pix.EndToEndID, Source: SourcePixWebhook, Resource: pixResource(pix.TxID, pix.EndToEndID), OccurredAt: at},
+				TxID:       pix.TxID,
+				EndToEndID: pix.EndToEndID,
+				Amount:     amount(pix.Valor),
+			})
+		}
+
+		for _, refund := range pix.Devolucoes {
+			events = append(events, refundEvents(SourcePixWebhook, pixResource(pix.TxID, pix.EndToEndID), pix.EndToEndID, refund.ID, refund.Status, refund.Valor, parseTime(refund.Horario.Liquidacao))...)
+		}
+	}
+
+	return events
+}
+
+func BillPaymentWebhookEvents(callback *efi.BillPaymentWebhookCallback) []Event {
+	meta := Metadata{
+		ID:         "bill:" + callback.Identifier + ":" + callback.Status.Current,
+		Source:     SourceBillPaymentWebhook,
+		Resource:   "bill:" + callback.Identifier,
+		OccurredAt: parseTime(callback.Timestamp.RequestTime),
+	}
+
+	switch efi.BillPaymentStatus(callback.Status.Current) {
+	case efi.BillPaymentStatusSettled:
+		return []Event{BillPaid{Metadata: meta, Identifier: callback.Identifier, Barcode: callback.EfiExtras.Barcode, Amount: amount(callback.Value)}}
+	case efi.BillPaymentStatusFailed, efi.BillPaymentStatusCanceled:
+		return []Event{BillFailed{Metadata: meta, Identifier: callback.Identifier, Barcode: callback.EfiExtras.Barcode, Status: callback.Status.Current}}
+	}
+
+	return nil
+}
+
+func OpenFinanceWebhookEvents(webhook *efi.OpenFinanceWebhookEvents) []Event {
+	var events []Event
+
+	for _, payment := range webhook.Payments {
+		meta := Metadata{
+			ID:         "open_finance:" + payment.PaymentID + ":" + string(payment.Status),
+			Source:     SourceOpenFinance,
+			Resource:   "open_finance:" + payment.PaymentID,
+			OccurredAt: parseTime(payment.CreatedAt),
+		}
+
+		switch payment.Status {
+		case efi.PaymentStatusCompleted:
+			events = append(events, ChargePaid{Metadata: meta, EndToEndID: payment.EndToEndID, Reference: payment.OwnID, Amount: amount(payment.Value)})
+		case efi.PaymentStatusRejected:
+			events = append(events, ChargeFailed{Metadata: meta, Reference: payment.OwnID, Reason: payment.Error})
+		}
+	}
+
+	for _, refund := range webhook.Refunds {
+		meta := Metadata{
+			ID:         "open_finance_refund:" + refund.RefundID + ":" + string(refund.Status),
+			Source:     SourceOpenFinance,
+			Resource:   "open_finance:" + refund.PaymentID,
+			OccurredAt: parseTime(refund.CreatedAt),
+		}
+
+		switch refund.Status {
+		case efi.PaymentStatusCompleted:
+			events = append(events, RefundCompleted{Metadata: meta, RefundID: refund.RefundID, EndToEndID: refund.EndToEndID, Amount: amount(refund.Value)})
+		case efi.PaymentStatusRejected:
+			events = append(events, RefundFailed{Metadata: meta, RefundID: refund.RefundID, EndToEndID: refund.EndToEndID, Reason: refund.Error})
+		}
+	}
+
+	return events
+}
+
+func StatusEvents(status *efi.TransactionStatus) []Event {
+	meta := Metadata{
+		ID:         "status:" + string(status.Type) + ":" + status.ID + ":" + status.Status,
+		Source:     SourceStatusPolling,
+		OccurredAt: time.Now(),
+	}
+
+	switch status.Type {
+	case efi.TransactionTypeCharge, efi.TransactionTypeDueCharge:
+		meta.Resource = pixResource(status.ID, status.EndToEndID)
+		switch status.Status {
+		case efi.StatusChargeCompleted:
+			return []Event{ChargePaid{Metadata: meta, TxID: status.ID, EndToEndID: status.EndToEndID}}
+		case efi.StatusChargeRemovedByPSP:
+			return []Event{ChargeExpired{Metadata: meta, TxID: status.ID}}
+		case efi.StatusChargeRemovedByUser:
+			return []Event{ChargeCanceled{Metadata: meta, TxID: status.ID, Reason: status.Message}}
+		}
+	case efi.TransactionTypePixSend:
+		meta.Resource = "pix_send:" + status.ID
+		switch status.Status {
+		case efi.StatusPixSendCompleted:
+			return []Event{PixSent{Metadata: meta, IDEnvio: status.ID}}
+		case efi.StatusPixSendFailed:
+			return []Event{PixSendFailed{Metadata: meta, IDEnvio: status.ID, Reason: status.Message}}
+		}
+	case efi.TransactionTypeRefund:
+		endToEndID, refundID, _ := strings.Cut(status.ID, ":")
+		meta.Resource = pixResource("", endToEndID)
+		switch status.Status {
+		case efi.StatusRefundCompleted:
+			return []Event{RefundCompleted{Metadata: meta, RefundID: refundID, EndToEndID: endToEndID}}
+		case efi.StatusRefundFailed:
+			return []Event{RefundFailed{Metadata: meta, RefundID: refundID, EndToEndID: endToEndID, Reason: status.Message}}
+		}
+	}
+
+	return nil
+}
+
+func PixWebhookHandler(bus *Bus) http.Handler {
+	return webhookHandler(bus, func(payload []byte) ([]Event, error) {
+		callback, err := efi.ParsePixWebhookCallback(payload)
+		if err != nil {
+			return nil, err
+		}
+		return PixWebhookEvents(callback), nil
+	})
+}
+
+func BillPaymentWebhookHandler(bus *Bus) http.Handler {
+	return webhookHandler(bus, func(payload []byte) ([]Event, error) {
+		callback, err := efi.ParseBillPaymentWebhookCallback(payload)
+		if err != nil {
+			return nil, err
+		}
+		return BillPaymentWebhookEvents(callback), nil
+	})
+}
+
+func AttachOpenFinance(bus *Bus, handler *efi.OpenFinanceWebhookHandler) {
+	handler.OnPayment(func(payment efi.OpenFinancePaymentEvent) error {
+		return bus.Publish(context.Background(), OpenFinanceWebhookEvents(&efi.OpenFinanceWebhookEvents{Payments: []efi.OpenFinancePaymentEvent{payment}})...)
+	})
+	handler.OnRefund(func(refund efi.OpenFinanceRefundEvent) error {
+		return bus.Publish(context.Background(), OpenFinanceWebhookEvents(&efi.OpenFinanceWebhookEvents{Refunds: []efi.OpenFinanceRefundEvent{refund}})...)
+	})
+}
+
+func PublishStatus(ctx context.Context, bus *Bus, client *efi.Client, id string, txType efi.TransactionType) error {
+	status, err := client.VerifyStatus(id, txType)
+	if err != nil {
+		return err
+	}
+	return bus.Publish(ctx, StatusEvents(status)...)
+}
+
+func ForwardStatusChanges(ctx context.Context, bus *Bus, changes <-chan efi.StatusChange) error {
+	for change := range changes {
+		if change.Err != nil || change.Status == nil {
+			continue
+		}
+		if err := bus.Publish(ctx, StatusEvents(change.Status)...); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func webhookHandler(bus *Bus, parse func(payload []byte) ([]Event, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		payload, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+
+		events, err := parse(payload)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := bus.Publish(r.Context(), events...); err != nil {
+			http.Error(w, fmt.Sprintf("failed to publish events: %v", err), http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func pixResource(txid, endToEndID string) string {
+	if endToEndID != "" {
+		return "pix:" + endToEndID
+	}
+	return "charge:" + txid
+}
+
+func refundEvents(source Source, resource, endToEndID, refundID, status, value string, at time.Time) []Event {
+	meta := Metadata{Source: source, Resource: resource, OccurredAt: at}
+
+	switch status {
+	case efi.StatusRefundCompleted:
+		meta.ID = string(TypeRefundCompleted) + ":" + endToEndID + ":" + refundID
+		return []Event{RefundCompleted{Metadata: meta, RefundID: refundID, EndToEndID: endToEndID, Amount: amount(value)}}
+	case efi.StatusRefundFailed:
+		meta.ID = string(TypeRefundFailed) + ":" + endToEndID + ":" + refundID
+		return []Event{RefundFailed{Metadata: meta, RefundID: refundID, EndToEndID: endToEndID}}
+	}
+
+	return nil
+}
+
+func amount(value string) int64 {
+	cents, _ := efi.ParseAmount(value)
+	return cents
+}
+
+func parseTime(value string) time.Time {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t
+	}
+	return time.Now()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,137 @@
+package events
+
+import "time"
+
+type Type string
+
+const (
+	TypeChargePaid      Type = "charge.paid"
+	TypeChargeExpired   Type = "charge.expired"
+	TypeChargeCanceled  Type = "charge.canceled"
+	TypeChargeFailed    Type = "charge.failed"
+	TypePixSent         Type = "pix.sent"
+	TypePixSendFailed   Type = "pix.send_failed"
+	TypeRefundCompleted Type = "refund.completed"
+	TypeRefundFailed    Type = "refund.failed"
+	TypeBillPaid        Type = "bill.paid"
+	TypeBillFailed      Type = "bill.failed"
+)
+
+type Source string
+
+const (
+	SourcePixWebhook         Source = "efi.pix_webhook"
+	SourceBillPaymentWebhook Source = "efi.bill_payment_webhook"
+	SourceOpenFinance        Source = "efi.open_finance"
+	SourceStatusPolling      Source = "efi.status_polling"
+	SourceMercadoPago        Source = "mercadopago"
+)
+
+type Event interface {
+	Type() Type
+	ResourceID() string
+	Meta() Metadata
+}
+
+type Metadata struct {
+	ID         string
+	Source     Source
+	Resource   string
+	OccurredAt time.Time
+}
+
+func (m Metadata) ResourceID() string {
+	return m.Resource
+}
+
+func (m Metadata) Meta() Metadata {
+	return m
+}
+
+type ChargePaid struct {
+	Metadata
+	TxID       string
+	EndToEndID string
+	Reference  string
+	Amount     int64
+}
+
+func (ChargePaid) Type() Type { return TypeChargePaid }
+
+type ChargeExpired struct {
+	Metadata
+	TxID string
+}
+
+func (ChargeExpired) Type() Type { return TypeChargeExpired }
+
+type ChargeCanceled struct {
+	Metadata
+	TxID   string
+	Reason string
+}
+
+func (ChargeCanceled) Type() Type { return TypeChargeCanceled }
+
+type ChargeFailed struct {
+	Metadata
+	Reference string
+	Reason    string
+}
+
+func (ChargeFailed) Type() Type { return TypeChargeFailed }
+
+type PixSent struct {
+	Metadata
+	IDEnvio    string
+	EndToEndID string
+	Amount     int64
+}
+
+func (PixSent) Type() Type { return TypePixSent }
+
+type PixSendFailed struct {
+	Metadata
+	IDEnvio    string
+	EndToEndID string
+	Reason     string
+}
+
+func (PixSendFailed) Type() Type { return TypePixSendFailed }
+
+type RefundCompleted struct {
+	Metadata
+	RefundID   string
+	EndToEndID string
+	Amount     int64
+}
+
+func (RefundCompleted) Type() Type { return TypeRefundCompleted }
+
+type RefundFailed struct {
+	Metadata
+	RefundID   string
+	EndToEndID string
+	Reason     string
+}
+
+func (RefundFailed) Type() Type { return TypeRefundFailed }
+
+type BillPaid struct {
+	Metadata
+	Identifier string
+	Barcode    string
+	Amount     int64
+}
+
+func (BillPaid) Type() Type { return TypeBillPaid }
+
+type BillFailed struct {
+	Metadata
+	Identifier string
+	Barcode    string
+	Status     string
+	Reason     string
+}
+
+func (BillFailed) Type() Type { return TypeBillFailed }
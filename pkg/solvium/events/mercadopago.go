@@ -0,0 +1,72 @@
+package events
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/solviumdream/solviumpayments/pkg/solvium/mercadopago"
+)
+
+func MercadoPagoPaymentEvents(payment *mercadopago.PaymentConsultResponse) []Event {
+	meta := Metadata{
+		ID:         "mercadopago:" + payment.ID + ":" + payment.Status,
+		Source:     SourceMercadoPago,
+		Resource:   "mercadopago:" + payment.ID,
+		OccurredAt: parseMercadoPagoTime(payment.DateLastUpdated),
+	}
+	cents := int64(math.Round(payment.TransactionAmount * 100))
+
+	switch payment.Status {
+	case mercadopago.PaymentStatusApproved:
+		meta.OccurredAt = parseMercadoPagoTime(firstNonEmpty(payment.DateApproved, payment.DateLastUpdated))
+		return []Event{ChargePaid{Metadata: meta, Reference: payment.ExternalReference, Amount: cents}}
+	case mercadopago.PaymentStatusRejected:
+		return []Event{ChargeFailed{Metadata: meta, Reference: payment.ExternalReference, Reason: payment.StatusDetail}}
+	case mercadopago.PaymentStatusCancelled:
+		if payment.StatusDetail == "expired" {
+			return []Event{ChargeExpired{Metadata: meta}}
+		}
+		return []Event{ChargeCanceled{Metadata: meta, Reason: payment.StatusDetail}}
+	case mercadopago.PaymentStatusRefunded:
+		return []Event{RefundCompleted{Metadata: meta, RefundID: payment.ID, Amount: cents}}
+	}
+
+	return nil
+}
+
+func MercadoPagoNotificationHandler(bus *Bus, client *mercadopago.Client) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		notification, err := mercadopago.ParseNotificationRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if notification.Type != mercadopago.NotificationTypePayment {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		payment, err := client.Payment().Consult(notification.ResourceID())
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to consult payment: %v", err), http.StatusBadGateway)
+			return
+		}
+
+		if err := bus.Publish(r.Context(), MercadoPagoPaymentEvents(payment)...); err != nil {
+			http.Error(w, fmt.Sprintf("failed to publish events: %v", err), http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func parseMercadoPagoTime(value string) time.Time {
+	if t, err := time.Parse("2006-01-02T15:04:05.000-07:00", value); err == nil {
+		return t
+	}
+	return parseTime(value)
+}
@@ -0,0 +1,66 @@
+package inbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/solviumdream/solviumpayments/pkg/solvium/efi"
+	"github.com/solviumdream/solviumpayments/pkg/solvium/events"
+	"github.com/solviumdream/solviumpayments/pkg/solvium/mercadopago"
+)
+
+func (i *Inbox) DispatchTo(bus *events.Bus, mp *mercadopago.Client) {
+	i.Handle(SourcePix, func(ctx context.Context, entry Entry) error {
+		callback, err := efi.ParsePixWebhookCallback(entry.Payload)
+		if err != nil {
+			return err
+		}
+		return dispatch(ctx, bus, events.PixWebhookEvents(callback))
+	})
+
+	i.Handle(SourceBillPayment, func(ctx context.Context, entry Entry) error {
+		callback, err := efi.ParseBillPaymentWebhookCallback(entry.Payload)
+		if err != nil {
+			return err
+		}
+		return dispatch(ctx, bus, events.BillPaymentWebhookEvents(callback))
+	})
+
+	i.Handle(SourceOpenFinance, func(ctx context.Context, entry Entry) error {
+		webhook, err := efi.ParseOpenFinanceWebhook(entry.Payload)
+		if err != nil {
+			return err
+		}
+		return dispatch(ctx, bus, events.OpenFinanceWebhookEvents(webhook))
+	})
+
+	if mp == nil {
+		return
+	}
+
+	i.Handle(SourceMercadoPago, func(ctx context.Context, entry Entry) error {
+		var notification mercadopago.Notification
+		if err := json.Unmarshal(entry.Payload, &notification); err != nil {
+			return fmt.Errorf("failed to decode notification: %w", err)
+		}
+		if notification.Type != mercadopago.NotificationTypePayment {
+			return nil
+		}
+
+		payment, err := mp.Payment().Consult(notification.ResourceID())
+		if err != nil {
+			return err
+		}
+		return dispatch(ctx, bus, events.MercadoPagoPaymentEvents(payment))
+	})
+}
+
+func dispatch(ctx context.Context, bus *events.Bus, evts []events.Event) error {
+	for _, event := range evts {
+		if err := bus.Dispatch(ctx, event); err != nil {
+			return fmt.Errorf("failed to handle %s for %s: %w", event.Type(), event.ResourceID(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package inbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	SourcePix         = "efi.pix"
+	SourceBillPayment = "efi.bill_payment"
+	SourceOpenFinance = "efi.open_finance"
+	SourceMercadoPago = "mercadopago"
+)
+
+type Handler func(ctx context.Context, entry Entry) error
+
+type Inbox struct {
+	store Store
+
+	MaxAttempts  int
+	BatchSize    int
+	Backoff      func(attempt int) time.Duration
+	OnDeadLetter func(entry Entry)
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+	process  sync.Mutex
+}
+
+func New(store Store) *Inbox {
+	return &Inbox{
+		store:       store,
+		MaxAttempts: 8,
+		BatchSize:   100,
+		Backoff:     ExponentialBackoff(time.Second, time.Hour),
+		handlers:    make(map[string]Handler),
+	}
+}
+
+func ExponentialBackoff(initial, max time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		delay := initial
+		for i := 1; i < attempt && delay < max; i++ {
+			delay *= 2
+		}
+		if delay > max {
+			delay = max
+		}
+		return delay
+	}
+}
+
+func (i *Inbox) Handle(source string, handler Handler) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.handlers[source] = handler
+}
+
+func (i *Inbox) Receive(source, key string, payload []byte) (bool, error) {
+	if key == "" {
+		return false, fmt.Errorf("inbox entry from %s has no key", source)
+	}
+	if !json.Valid(payload) {
+		return false, fmt.Errorf("inbox entry %s has an invalid JSON payload", key)
+	}
+
+	now := time.Now()
+	inserted, err := i.store.Insert(&Entry{
+		Key:           key,
+		Source:        source,
+		Payload:       append(json.RawMessage(nil), payload...),
+		Status:        StatusPending,
+		ReceivedAt:    now,
+		NextAttemptAt: now,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to persist inbox entry: %w", err)
+	}
+	return inserted, nil
+}
+
+func (i *Inbox) ProcessDue(ctx context.Context) (int, error) {
+	i.process.Lock()
+	defer i.process.Unlock()
+
+	entries, err := i.store.Due(time.Now(), i.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load inbox entries: %w", err)
+	}
+
+	processed := 0
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return processed, err
+		}
+
+		if err := i.processEntry(ctx, entry); err != nil {
+			return processed, err
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+func (i *Inbox) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := i.ProcessDue(ctx); err != nil && ctx.Err() == nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (i *Inbox) DeadLetters() ([]Entry, error) {
+	return i.store.DeadLetters()
+}
+
+func (i *Inbox) Requeue(key string) error {
+	entry, err := i.store.Get(key)
+	if err != nil {
+		return err
+	}
+	if entry.Status != StatusDead {
+		return fmt.Errorf("inbox entry %s is %s, not dead", key, entry.Status)
+	}
+
+	entry.Status = StatusPending
+	entry.Attempts = 0
+	entry.NextAttemptAt = time.Now()
+	return i.store.Update(entry)
+}
+
+func (i *Inbox) processEntry(ctx context.Context, entry Entry) error {
+	i.mu.RLock()
+	handler, ok := i.handlers[entry.Source]
+	i.mu.RUnlock()
+
+	var handlerErr error
+	if ok {
+		handlerErr = i.call(ctx, handler, entry)
+	} else {
+		handlerErr = fmt.Errorf("no handler registered for source %s", entry.Source)
+	}
+
+	now := time.Now()
+	entry.Attempts++
+	if handlerErr == nil {
+		entry.Status = StatusProcessed
+		entry.LastError = ""
+		entry.ProcessedAt = now
+	} else {
+		entry.LastError = handlerErr.Error()
+		if entry.Attempts >= i.MaxAttempts {
+			entry.Status = StatusDead
+		} else {
+			entry.NextAttemptAt = now.Add(i.Backoff(entry.Attempts))
+		}
+	}
+
+	if err := i.store.Update(&entry); err != nil {
+		return fmt.Errorf("failed to update inbox entry: %w", err)
+	}
+
+	if entry.Status == StatusDead && i.OnDeadLetter != nil {
+		i.OnDeadLetter(entry)
+	}
+	return nil
+}
+
+func (i *Inbox) call(ctx context.Context, handler Handler, entry Entry) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("inbox handler panicked: %v", r)
+		}
+	}()
+	return handler(ctx, entry)
+}
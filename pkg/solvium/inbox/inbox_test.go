@@ -0,0 +1,159 @@
+package inbox
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestInboxDedupesPixByEndToEndID(t *testing.T) {
+	box := New(NewMemoryStore())
+
+	payload := []byte(`{"pix":[{"endToEndId":"E1","txid":"tx1","valor":"10.00"},{"endToEndId":"E2","valor":"5.00"}]}`)
+	accepted, err := box.ReceivePixWebhook(payload)
+	if err != nil || accepted != 2 {
+		t.Fatalf("first delivery: accepted %d, err %v", accepted, err)
+	}
+
+	accepted, err = box.ReceivePixWebhook(payload)
+	if err != nil || accepted != 0 {
+		t.Fatalf("redelivery: accepted %d, err %v", accepted, err)
+	}
+
+	accepted, err = box.ReceivePixWebhook([]byte(`{"pix":[{"endToEndId":"E1","txid":"tx1","valor":"10.00","devolucoes":[{"id":"d1","status":"DEVOLVIDO"}]}]}`))
+	if err != nil || accepted != 1 {
+		t.Fatalf("refund update: accepted %d, err %v", accepted, err)
+	}
+}
+
+func TestInboxRetriesAndDeadLetters(t *testing.T) {
+	box := New(NewMemoryStore())
+	box.MaxAttempts = 3
+	box.Backoff = func(int) time.Duration { return 0 }
+
+	calls := 0
+	box.Handle(SourceBillPayment, func(ctx context.Context, entry Entry) error {
+		calls++
+		return errors.New("downstream unavailable")
+	})
+
+	if _, err := box.ReceiveBillPaymentWebhook([]byte(`{"identificador":"b1","status":{"anterior":"PROCESSANDO","atual":"LIQUIDADO"},"valor":"10.00"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	for n := 0; n < 5; n++ {
+		if _, err := box.ProcessDue(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls)
+	}
+
+	dead, _ := box.DeadLetters()
+	if len(dead) != 1 || dead[0].LastError != "downstream unavailable" {
+		t.Fatalf("unexpected dead letters %+v", dead)
+	}
+
+	box.Handle(SourceBillPayment, func(ctx context.Context, entry Entry) error { return nil })
+	if err := box.Requeue(dead[0].Key); err != nil {
+		t.Fatal(err)
+	}
+	if processed, err := box.ProcessDue(context.Background()); err != nil || processed != 1 {
+		t.Fatalf("requeued: processed %d, err %v", processed, err)
+	}
+
+	entry, _ := box.store.Get(dead[0].Key)
+	if entry.Status != StatusProcessed {
+		t.Fatalf("expected processed entry, got %s", entry.Status)
+	}
+}
+
+func TestFileStorePersistsEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inbox.json")
+
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(store).Receive(SourcePix, "pix:E1", []byte(`{"pix":[]}`)); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	reopened, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	inserted, err := reopened.Insert(&Entry{Key: "pix:E1"})
+	if err != nil || inserted {
+		t.Fatalf("expected duplicate after reopen, inserted %v err %v", inserted, err)
+	}
+
+	due, _ := reopened.Due(time.Now(), 0)
+	if len(due) != 1 || due[0].Source != SourcePix {
+		t.Fatalf("unexpected due entries %+v", due)
+	}
+}
+
+func TestFileStoreCompactsAndPrunesProcessedEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inbox.json")
+
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.CompactEvery = 3
+
+	now := time.Now()
+	entries := []Entry{
+		{Key: "old", Status: StatusProcessed, ProcessedAt: now.Add(-DefaultRetention - time.Hour)},
+		{Key: "recent", Status: StatusProcessed, ProcessedAt: now.Add(-time.Hour)},
+		{Key: "dead", Status: StatusDead, ReceivedAt: now.Add(-DefaultRetention - time.Hour)},
+	}
+	for i := range entries {
+		if _, err := store.Insert(&entries[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if info, err := os.Stat(path + ".log"); err != nil || info.Size() != 0 {
+		t.Fatalf("expected log to be compacted, got %v %v", info, err)
+	}
+	if _, err := store.Get("old"); !errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf("expected old processed entry to be pruned, got %v", err)
+	}
+
+	if _, err := store.Insert(&Entry{Key: "pending", Status: StatusPending}); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	log, err := os.OpenFile(path+".log", os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.WriteString(`{"key":"torn","stat`)
+	log.Close()
+
+	reopened, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+
+	for _, key := range []string{"recent", "dead", "pending"} {
+		if _, err := reopened.Get(key); err != nil {
+			t.Fatalf("expected %s to survive reopen: %v", key, err)
+		}
+	}
+	for _, key := range []string{"old", "torn"} {
+		if _, err := reopened.Get(key); !errors.Is(err, ErrEntryNotFound) {
+			t.Fatalf("expected %s to be gone, got %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package inbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/solviumdream/solviumpayments/pkg/solvium/efi"
+	"github.com/solviumdream/solviumpayments/pkg/solvium/mercadopago"
+)
+
+const maxWebhookBody = 1 << 20
+
+func PixKey(pix efi.PixWebhookPix) string {
+	if pix.IsSend() {
+		id := pix.EndToEndID
+		if pix.GnExtras != nil && pix.GnExtras.IDEnvio != "" {
+			id = pix.GnExtras.IDEnvio
+		}
+		return "pix_send:" + id + ":" + pix.Status
+	}
+
+	key := "pix:" + pix.EndToEndID
+	for _, refund := range pix.Devolucoes {
+		key += ":" + refund.ID + "=" + refund.Status
+	}
+	return key
+}
+
+func BillPaymentKey(callback *efi.BillPaymentWebhookCallback) string {
+	return "bill:" + callback.Identifier + ":" + callback.Status.Previous + ">" + callback.Status.Current
+}
+
+func OpenFinancePaymentKey(payment efi.OpenFinancePaymentEvent) string {
+	return "open_finance:" + payment.PaymentID + ":" + string(payment.Status)
+}
+
+func OpenFinanceRefundKey(refund efi.OpenFinanceRefundEvent) string {
+	return "open_finance_refund:" + refund.PaymentID + ":" + refund.RefundID + ":" + string(refund.Status)
+}
+
+func MercadoPagoKey(notification *mercadopago.Notification) string {
+	if notification.ID != "" {
+		return "mercadopago:" + notification.ID.String()
+	}
+	return "mercadopago:" + notification.Type + ":" + notification.ResourceID() + ":" + notification.Action
+}
+
+func (i *Inbox) ReceivePixWebhook(payload []byte) (int, error) {
+	callback, err := efi.ParsePixWebhookCallback(payload)
+	if err != nil {
+		return 0, err
+	}
+	return i.receivePix(callback)
+}
+
+func (i *Inbox) receivePix(callback *efi.PixWebhookCallback) (int, error) {
+	accepted := 0
+	for _, pix := range callback.Pix {
+		single, err := json.Marshal(efi.PixWebhookCallback{Pix: []efi.PixWebhookPix{pix}})
+		if err != nil {
+			return accepted, fmt.Errorf("failed to encode pix callback: %w", err)
+		}
+
+		inserted, err := i.Receive(SourcePix, PixKey(pix), single)
+		if err != nil {
+			return accepted, err
+		}
+		if inserted {
+			accepted++
+		}
+	}
+
+	return accepted, nil
+}
+
+func (i *Inbox) ReceiveBillPaymentWebhook(payload []byte) (bool, error) {
+	callback, err := efi.ParseBillPaymentWebhookCallback(payload)
+	if err != nil {
+		return false, err
+	}
+	return i.Receive(SourceBillPayment, BillPaymentKey(callback), payload)
+}
+
+func (i *Inbox) ReceiveOpenFinanceWebhook(payload []byte) (int, error) {
+	events, err := efi.ParseOpenFinanceWebhook(payload)
+	if err != nil {
+		return 0, err
+	}
+	return i.receiveOpenFinance(events)
+}
+
+func (i *Inbox) receiveOpenFinance(events *efi.OpenFinanceWebhookEvents) (int, error) {
+	accepted := 0
+	receive := func(key string, value interface{}) error {
+		single, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode open finance callback: %w", err)
+		}
+		inserted, err := i.Receive(SourceOpenFinance, key, single)
+		if inserted {
+			accepted++
+		}
+		return err
+	}
+
+	for _, payment := range events.Payments {
+		payment.Type = efi.OpenFinanceWebhookEventPayment
+		if err := receive(OpenFinancePaymentKey(payment), payment); err != nil {
+			return accepted, err
+		}
+	}
+	for _, refund := range events.Refunds {
+		refund.Type = efi.OpenFinanceWebhookEventRefund
+		if err := receive(OpenFinanceRefundKey(refund), refund); err != nil {
+			return accepted, err
+		}
+	}
+
+	return accepted, nil
+}
+
+func (i *Inbox) ReceiveMercadoPagoNotification(notification *mercadopago.Notification) (bool, error) {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode notification: %w", err)
+	}
+	return i.Receive(SourceMercadoPago, MercadoPagoKey(notification), payload)
+}
+
+func (i *Inbox) PixWebhookHandler() http.Handler {
+	return i.webhookHandler(func(r *http.Request, payload []byte) error {
+		callback, err := efi.ParsePixWebhookCallback(payload)
+		if err != nil {
+			return &rejectedError{status: http.StatusBadRequest, err: err}
+		}
+		_, err = i.receivePix(callback)
+		return err
+	})
+}
+
+func (i *Inbox) BillPaymentWebhookHandler() http.Handler {
+	return i.webhookHandler(func(r *http.Request, payload []byte) error {
+		callback, err := efi.ParseBillPaymentWebhookCallback(payload)
+		if err != nil {
+			return &rejectedError{status: http.StatusBadRequest, err: err}
+		}
+		_, err = i.Receive(SourceBillPayment, BillPaymentKey(callback), payload)
+		return err
+	})
+}
+
+func (i *Inbox) OpenFinanceWebhookHandler(verifier *efi.OpenFinanceWebhookHandler) http.Handler {
+	return i.webhookHandler(func(r *http.Request, payload []byte) error {
+		if verifier != nil {
+			if err := verifier.Verify(r, payload); err != nil {
+				return &rejectedError{status: http.StatusUnauthorized, err: err}
+			}
+		}
+		events, err := efi.ParseOpenFinanceWebhook(payload)
+		if err != nil {
+			return &rejectedError{status: http.StatusBadRequest, err: err}
+		}
+		_, err = i.receiveOpenFinance(events)
+		return err
+	})
+}
+
+func (i *Inbox) MercadoPagoHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxWebhookBody)
+
+		notification, err := mercadopago.ParseNotificationRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if _, err := i.ReceiveMercadoPagoNotification(notification); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+type rejectedError struct {
+	status int
+	err    error
+}
+
+func (e *rejectedError) Error() string {
+	return e.err.Error()
+}
+
+func (i *Inbox) webhookHandler(receive func(r *http.Request, payload []byte) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		payload, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+
+		if err := receive(r, payload); err != nil {
+			status := http.StatusInternalServerError
+			if rejected, ok := err.(*rejectedError); ok {
+				status = rejected.status
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,368 @@
+package inbox
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+var ErrEntryNotFound = errors.New("inbox entry not found")
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusProcessed Status = "processed"
+	StatusDead      Status = "dead"
+)
+
+type Entry struct {
+	Key           string          `json:"key"`
+	Source        string          `json:"source"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        Status          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	LastError     string          `json:"lastError,omitempty"`
+	ReceivedAt    time.Time       `json:"receivedAt"`
+	NextAttemptAt time.Time       `json:"nextAttemptAt"`
+	ProcessedAt   time.Time       `json:"processedAt"`
+}
+
+type Store interface {
+	Insert(entry *Entry) (bool, error)
+	Get(key string) (*Entry, error)
+	Update(entry *Entry) error
+	Due(now time.Time, limit int) ([]Entry, error)
+	DeadLetters() ([]Entry, error)
+}
+
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]Entry
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]Entry)}
+}
+
+func (s *MemoryStore) Insert(entry *Entry) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.entries[entry.Key]; ok {
+		return false, nil
+	}
+	s.entries[entry.Key] = *entry
+	return true, nil
+}
+
+func (s *MemoryStore) Get(key string) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, ErrEntryNotFound
+	}
+	return &entry, nil
+}
+
+func (s *MemoryStore) Update(entry *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.entries[entry.Key]; !ok {
+		return ErrEntryNotFound
+	}
+	s.entries[entry.Key] = *entry
+	return nil
+}
+
+func (s *MemoryStore) Due(now time.Time, limit int) ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return selectEntries(s.entries, func(entry Entry) bool {
+		return entry.Status == StatusPending && !entry.NextAttemptAt.After(now)
+	}, limit), nil
+}
+
+func (s *MemoryStore) DeadLetters() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return selectEntries(s.entries, func(entry Entry) bool {
+		return entry.Status == StatusDead
+	}, 0), nil
+}
+
+const (
+	DefaultRetention    = 30 * 24 * time.Hour
+	DefaultCompactEvery = 1000
+)
+
+type FileStore struct {
+	Retention    time.Duration
+	CompactEvery int
+
+	mu      sync.Mutex
+	path    string
+	entries map[string]Entry
+	log     *os.File
+	logSize int64
+	records int
+}
+
+func OpenFileStore(path string) (*FileStore, error) {
+	store := &FileStore{
+		Retention:    DefaultRetention,
+		CompactEvery: DefaultCompactEvery,
+		path:         path,
+		entries:      make(map[string]Entry),
+	}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("failed to read inbox file: %w", err)
+	default:
+		var entries []Entry
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, fmt.Errorf("failed to decode inbox file: %w", err)
+		}
+		for _, entry := range entries {
+			store.entries[entry.Key] = entry
+		}
+	}
+
+	if err := store.replay(); err != nil {
+		return nil, err
+	}
+
+	store.log, err = os.OpenFile(path+".log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open inbox log: %w", err)
+	}
+
+	info, err := store.log.Stat()
+	if err != nil {
+		store.log.Close()
+		return nil, fmt.Errorf("failed to read inbox log: %w", err)
+	}
+	if info.Size() > 0 {
+		if err := store.compact(time.Now()); err != nil {
+			store.log.Close()
+			return nil, err
+		}
+	}
+
+	return store, nil
+}
+
+func (s *FileStore) Insert(entry *Entry) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.entries[entry.Key]; ok {
+		return false, nil
+	}
+
+	if err := s.append(*entry); err != nil {
+		return false, err
+	}
+	s.entries[entry.Key] = *entry
+	return true, s.maybeCompact()
+}
+
+func (s *FileStore) Get(key string) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, ErrEntryNotFound
+	}
+	return &entry, nil
+}
+
+func (s *FileStore) Update(entry *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.entries[entry.Key]; !ok {
+		return ErrEntryNotFound
+	}
+
+	if err := s.append(*entry); err != nil {
+		return err
+	}
+	s.entries[entry.Key] = *entry
+	return s.maybeCompact()
+}
+
+func (s *FileStore) Due(now time.Time, limit int) ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return selectEntries(s.entries, func(entry Entry) bool {
+		return entry.Status == StatusPending && !entry.NextAttemptAt.After(now)
+	}, limit), nil
+}
+
+func (s *FileStore) DeadLetters() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return selectEntries(s.entries, func(entry Entry) bool {
+		return entry.Status == StatusDead
+	}, 0), nil
+}
+
+func (s *FileStore) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.compact(time.Now())
+}
+
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.log.Close()
+}
+
+func (s *FileStore) replay() error {
+	file, err := os.Open(s.path + ".log")
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to read inbox log: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read inbox log: %w", err)
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return fmt.Errorf("failed to decode inbox log: %w", err)
+		}
+		s.entries[entry.Key] = entry
+		s.records++
+	}
+}
+
+func (s *FileStore) append(entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode inbox entry: %w", err)
+	}
+	data = append(data, '\n')
+
+	if _, err := s.log.Write(data); err != nil {
+		s.log.Truncate(s.logSize)
+		return fmt.Errorf("failed to write inbox log: %w", err)
+	}
+	if err := s.log.Sync(); err != nil {
+		s.log.Truncate(s.logSize)
+		return fmt.Errorf("failed to sync inbox log: %w", err)
+	}
+
+	s.logSize += int64(len(data))
+	s.records++
+	return nil
+}
+
+func (s *FileStore) maybeCompact() error {
+	if s.CompactEvery <= 0 || s.records < s.CompactEvery {
+		return nil
+	}
+	return s.compact(time.Now())
+}
+
+func (s *FileStore) compact(now time.Time) error {
+	if s.Retention > 0 {
+		cutoff := now.Add(-s.Retention)
+		for key, entry := range s.entries {
+			if entry.Status == StatusProcessed && entry.ProcessedAt.Before(cutoff) {
+				delete(s.entries, key)
+			}
+		}
+	}
+
+	if err := s.writeSnapshot(); err != nil {
+		return err
+	}
+
+	if err := s.log.Truncate(0); err != nil {
+		return fmt.Errorf("failed to truncate inbox log: %w", err)
+	}
+	if err := s.log.Sync(); err != nil {
+		return fmt.Errorf("failed to sync inbox log: %w", err)
+	}
+	s.logSize = 0
+	s.records = 0
+	return nil
+}
+
+func (s *FileStore) writeSnapshot() error {
+	data, err := json.Marshal(selectEntries(s.entries, func(Entry) bool { return true }, 0))
+	if err != nil {
+		return fmt.Errorf("failed to encode inbox file: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write inbox file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write inbox file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync inbox file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write inbox file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to replace inbox file: %w", err)
+	}
+	return nil
+}
+
+func selectEntries(entries map[string]Entry, match func(Entry) bool, limit int) []Entry {
+	var selected []Entry
+	for _, entry := range entries {
+		if match(entry) {
+			selected = append(selected, entry)
+		}
+	}
+
+	sort.Slice(selected, func(i, j int) bool {
+		if !selected[i].ReceivedAt.Equal(selected[j].ReceivedAt) {
+			return selected[i].ReceivedAt.Before(selected[j].ReceivedAt)
+		}
+		return selected[i].Key < selected[j].Key
+	})
+
+	if limit > 0 && len(selected) > limit {
+		selected = selected[:limit]
+	}
+	return selected
+}
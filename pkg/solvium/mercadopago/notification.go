@@ -0,0 +1,90 @@
+package mercadopago
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	NotificationTypePayment = "payment"
+
+	PaymentStatusPending    = "pending"
+	PaymentStatusApproved   = "approved"
+	PaymentStatusAuthorized = "authorized"
+	PaymentStatusInProcess  = "in_process"
+	PaymentStatusRejected   = "rejected"
+	PaymentStatusCancelled  = "cancelled"
+	PaymentStatusRefunded   = "refunded"
+	PaymentStatusChargeback = "charged_back"
+)
+
+type NotificationData struct {
+	ID json.Number `json:"id"`
+}
+
+type Notification struct {
+	ID          json.Number      `json:"id"`
+	Type        string           `json:"type"`
+	Action      string           `json:"action"`
+	APIVersion  string           `json:"api_version"`
+	LiveMode    bool             `json:"live_mode"`
+	DateCreated string           `json:"date_created"`
+	UserID      json.Number      `json:"user_id"`
+	Data        NotificationData `json:"data"`
+}
+
+func (n *Notification) ResourceID() string {
+	return n.Data.ID.String()
+}
+
+func ParseNotification(payload []byte) (*Notification, error) {
+	var notification Notification
+	if err := json.Unmarshal(payload, &notification); err != nil {
+		return nil, fmt.Errorf("failed to parse notification: %w", err)
+	}
+
+	return &notification, nil
+}
+
+func ParseNotificationRequest(r *http.Request) (*Notification, error) {
+	query := r.URL.Query()
+
+	var notification *Notification
+	if r.Body != nil && r.Method == http.MethodPost {
+		payload, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read notification: %w", err)
+		}
+		if len(payload) > 0 {
+			notification, err = ParseNotification(payload)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if notification == nil {
+		notification = &Notification{}
+	}
+	if notification.Type == "" {
+		notification.Type = query.Get("type")
+		if notification.Type == "" {
+			notification.Type = query.Get("topic")
+		}
+	}
+	if notification.Data.ID == "" {
+		id := query.Get("data.id")
+		if id == "" {
+			id = query.Get("id")
+		}
+		notification.Data.ID = json.Number(id)
+	}
+
+	if notification.Type == "" || notification.Data.ID == "" {
+		return nil, fmt.Errorf("notification is missing type or resource id")
+	}
+
+	return notification, nil
+}